@@ -4,14 +4,20 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
-	"strings"
+	"path/filepath"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/waabox/gitdeck/internal/apiclient"
 	"github.com/waabox/gitdeck/internal/auth"
 	"github.com/waabox/gitdeck/internal/config"
+	"github.com/waabox/gitdeck/internal/domain"
 	"github.com/waabox/gitdeck/internal/git"
+	"github.com/waabox/gitdeck/internal/logscan"
+	"github.com/waabox/gitdeck/internal/network"
 	"github.com/waabox/gitdeck/internal/provider"
 	githubprovider "github.com/waabox/gitdeck/internal/provider/github"
 	gitlabprovider "github.com/waabox/gitdeck/internal/provider/gitlab"
@@ -33,11 +39,18 @@ const defaultGitLabClientID = "9df6c8abe93dc879a79ecf7681909b4a37d5c61064190a795
 
 func main() {
 	versionFlag := flag.Bool("version", false, "print version and exit")
+	debugPath := flag.String("debug", "", "append a structured log of every HTTP request to `file`")
+	recordPath := flag.String("record", "", "record every provider response to the fixture `file` on exit")
+	replayPath := flag.String("replay", "", "serve provider responses from the fixture `file` instead of the API")
 	flag.Parse()
 	if *versionFlag {
 		fmt.Println("gitdeck", version)
 		os.Exit(0)
 	}
+	if *recordPath != "" && *replayPath != "" {
+		fmt.Fprintf(os.Stderr, "error: --record and --replay cannot be combined\n")
+		os.Exit(1)
+	}
 
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -45,41 +58,144 @@ func main() {
 		os.Exit(1)
 	}
 
+	configPath := config.DefaultConfigPath()
+	cfg, err := config.LoadFrom(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// A replay needs neither a git repository nor credentials.
+	if *replayPath != "" {
+		fixture, err := provider.LoadFixture(*replayPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error loading replay: %v\n", err)
+			os.Exit(1)
+		}
+		app, err := newApp(fixture.Repository, provider.NewReplayProvider(fixture), cfg, cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		if err := runTUI(app); err != nil {
+			fmt.Fprintf(os.Stderr, "gitdeck error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	repo, err := git.DetectRepository(cwd)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error detecting git remote: %v\n", err)
 		os.Exit(1)
 	}
 
-	configPath := config.DefaultConfigPath()
-	cfg, err := config.LoadFrom(configPath)
+	netOpts := networkOptions(cfg.Network)
+	if *debugPath != "" {
+		// stdout belongs to the TUI, so the trace goes to a file.
+		debugFile, err := os.OpenFile(*debugPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error opening debug log: %v\n", err)
+			os.Exit(1)
+		}
+		defer debugFile.Close()
+		netOpts.Trace = slog.New(slog.NewJSONHandler(debugFile, nil))
+	}
+	httpClient, err := network.NewClient(netOpts)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
 		os.Exit(1)
 	}
 
+	limit := cfg.PipelineLimitOrDefault()
+	var cacheDir string
+	if cfg.DiskCache {
+		cacheDir = config.DefaultCacheDir()
+	}
+
+	// Create adapters
+	githubAdapter := githubprovider.NewAdapter(cfg.GitHub.Token, "", limit)
+	githubAdapter.SetHTTPClient(httpClient)
+	if cacheDir != "" {
+		githubAdapter.SetCache(apiclient.NewDiskCache(filepath.Join(cacheDir, "github")))
+	}
+
+	// Wrap with refreshing logic
+	githubProvider := provider.NewRefreshingProvider(
+		githubAdapter, "github",
+		func() (string, error) { return "", fmt.Errorf("GitHub OAuth tokens cannot be refreshed") },
+		func(token string) { githubAdapter.SetToken(token) },
+	)
+
+	gitlabInstances, err := newGitLabInstances(&cfg, configPath, limit, httpClient, cacheDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	gitlabDefault := gitlabInstances[0]
+
+	// Overrides are registered first so they win over the built-in hosts.
+	registry := provider.NewRegistry()
+	providerTypes := map[string]domain.PipelineProvider{"github": githubProvider, "gitlab": gitlabDefault.provider}
+	for _, gl := range gitlabInstances[1:] {
+		if gl.name != "" {
+			providerTypes[gl.name] = gl.provider
+		}
+	}
+	for _, rc := range cfg.Remotes {
+		p, ok := providerTypes[rc.Provider]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "error loading config: unknown provider %q for remote %q (want \"github\", \"gitlab\" or a GitLab instance name)\n", rc.Provider, rc.Match)
+			os.Exit(1)
+		}
+		registry.Register(rc.Match, p)
+	}
+	registry.Register("github.com", githubProvider)
+	registry.Register("gitlab.com", gitlabDefault.provider)
+	for _, gl := range gitlabInstances {
+		if gl.url != "" {
+			registry.Register(gl.url, gl.provider)
+		}
+	}
+
+	ciProvider, err := registry.Detect(repo.RemoteURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error detecting CI provider: %v\n", err)
+		os.Exit(1)
+	}
+
+	// The GitLab server the repository lives on, for authentication.
+	gitlab := gitlabDefault
+	for _, gl := range gitlabInstances {
+		if ciProvider == gl.provider {
+			gitlab = gl
+		}
+	}
+
 	ctx := context.Background()
 
-	if strings.Contains(repo.RemoteURL, "github.com") && cfg.GitHub.Token == "" {
-		resp, authErr := runGitHubAuth(ctx, cfg.GitHub.ClientID)
+	if ciProvider == githubProvider && cfg.GitHub.Token == "" {
+		resp, authErr := runGitHubAuth(ctx, cfg.GitHub.ClientID, httpClient)
 		if authErr != nil {
 			fmt.Fprintf(os.Stderr, "GitHub authentication failed: %v\n", authErr)
 			os.Exit(1)
 		}
 		cfg.GitHub.Token = resp.AccessToken
+		githubAdapter.SetToken(resp.AccessToken)
 		if saveErr := config.Save(configPath, cfg); saveErr != nil {
 			fmt.Fprintf(os.Stderr, "warning: could not save token to config: %v (you will need to re-authenticate next run)\n", saveErr)
 		} else {
 			fmt.Fprintf(os.Stderr, "Authenticated. Token saved to %s\n", configPath)
 		}
-	} else if isGitLabRemote(repo.RemoteURL, cfg.GitLab.URL) && (cfg.GitLab.Token == "" || (cfg.GitLab.Token != "" && cfg.GitLab.RefreshToken == "")) {
-		resp, authErr := runGitLabAuth(ctx, cfg.GitLab.ClientID, cfg.GitLab.URL)
+	} else if ciProvider == gitlab.provider && (*gitlab.token == "" || *gitlab.refreshToken == "") {
+		resp, authErr := runGitLabAuth(ctx, gitlab.clientID, gitlab.url, httpClient)
 		if authErr != nil {
 			fmt.Fprintf(os.Stderr, "GitLab authentication failed: %v\n", authErr)
 			os.Exit(1)
 		}
-		cfg.GitLab.Token = resp.AccessToken
-		cfg.GitLab.RefreshToken = resp.RefreshToken
+		*gitlab.token = resp.AccessToken
+		*gitlab.refreshToken = resp.RefreshToken
+		gitlab.adapter.SetToken(resp.AccessToken)
 		if saveErr := config.Save(configPath, cfg); saveErr != nil {
 			fmt.Fprintf(os.Stderr, "warning: could not save token to config: %v (you will need to re-authenticate next run)\n", saveErr)
 		} else {
@@ -87,53 +203,29 @@ func main() {
 		}
 	}
 
-	limit := cfg.PipelineLimitOrDefault()
-	gitLabURL := cfg.GitLab.URL
-
-	// Create adapters
-	githubAdapter := githubprovider.NewAdapter(cfg.GitHub.Token, "", limit)
-	gitlabAdapter := gitlabprovider.NewAdapter(cfg.GitLab.Token, gitLabURL, limit)
-
-	// Create token manager for silent refresh
-	tokenManager := auth.NewTokenManager(&cfg, configPath, gitLabURL)
-
-	// Wrap with refreshing logic
-	githubProvider := provider.NewRefreshingProvider(
-		githubAdapter, "github",
-		func() (string, error) { return "", fmt.Errorf("GitHub OAuth tokens cannot be refreshed") },
-		func(token string) { githubAdapter.SetToken(token) },
-	)
-	gitlabProvider := provider.NewRefreshingProvider(
-		gitlabAdapter, "gitlab",
-		func() (string, error) { return tokenManager.RefreshGitLab(context.Background()) },
-		func(token string) { gitlabAdapter.SetToken(token) },
-	)
-
-	registry := provider.NewRegistry()
-	registry.Register("github.com", githubProvider)
-	registry.Register("gitlab.com", gitlabProvider)
-	if gitLabURL != "" {
-		registry.Register(gitLabURL, gitlabProvider)
+	var recorder *provider.RecordingProvider
+	if *recordPath != "" {
+		recorder = provider.NewRecordingProvider(ciProvider, repo)
+		ciProvider = recorder
 	}
 
-	ciProvider, err := registry.Detect(repo.RemoteURL)
+	app, err := newApp(repo, ciProvider, cfg, cwd)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "error detecting CI provider: %v\n", err)
+		fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
 		os.Exit(1)
 	}
-
-	app := tui.NewAppModel(repo, ciProvider)
 	app.OnRequestCode = func(ctx context.Context, providerName string) (auth.DeviceCodeResponse, error) {
 		var clientID string
 		var baseURL string
 		switch providerName {
 		case "gitlab":
-			clientID = cfg.GitLab.ClientID
+			clientID = gitlab.clientID
 			if clientID == "" {
 				clientID = defaultGitLabClientID
 			}
-			baseURL = cfg.GitLab.URL
+			baseURL = gitlab.url
 			flow := auth.NewGitLabDeviceFlow(clientID, baseURL)
+			flow.SetHTTPClient(httpClient)
 			return flow.RequestCode(ctx)
 		case "github":
 			clientID = cfg.GitHub.ClientID
@@ -141,6 +233,7 @@ func main() {
 				clientID = defaultGitHubClientID
 			}
 			flow := auth.NewGitHubDeviceFlow(clientID, "")
+			flow.SetHTTPClient(httpClient)
 			return flow.RequestCode(ctx)
 		}
 		return auth.DeviceCodeResponse{}, fmt.Errorf("unknown provider: %s", providerName)
@@ -149,11 +242,12 @@ func main() {
 		var clientID string
 		switch providerName {
 		case "gitlab":
-			clientID = cfg.GitLab.ClientID
+			clientID = gitlab.clientID
 			if clientID == "" {
 				clientID = defaultGitLabClientID
 			}
-			flow := auth.NewGitLabDeviceFlow(clientID, cfg.GitLab.URL)
+			flow := auth.NewGitLabDeviceFlow(clientID, gitlab.url)
+			flow.SetHTTPClient(httpClient)
 			return flow.PollToken(ctx, deviceCode, interval)
 		case "github":
 			clientID = cfg.GitHub.ClientID
@@ -161,6 +255,7 @@ func main() {
 				clientID = defaultGitHubClientID
 			}
 			flow := auth.NewGitHubDeviceFlow(clientID, "")
+			flow.SetHTTPClient(httpClient)
 			return flow.PollToken(ctx, deviceCode, interval)
 		}
 		return auth.TokenResponse{}, fmt.Errorf("unknown provider: %s", providerName)
@@ -168,9 +263,9 @@ func main() {
 	app.OnTokenRefreshed = func(providerName string, resp auth.TokenResponse) {
 		switch providerName {
 		case "gitlab":
-			cfg.GitLab.Token = resp.AccessToken
-			cfg.GitLab.RefreshToken = resp.RefreshToken
-			gitlabAdapter.SetToken(resp.AccessToken)
+			*gitlab.token = resp.AccessToken
+			*gitlab.refreshToken = resp.RefreshToken
+			gitlab.adapter.SetToken(resp.AccessToken)
 		case "github":
 			cfg.GitHub.Token = resp.AccessToken
 			githubAdapter.SetToken(resp.AccessToken)
@@ -178,29 +273,144 @@ func main() {
 		config.Save(configPath, cfg)
 	}
 
-	p := tea.NewProgram(app, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "gitdeck error: %v\n", err)
+	// The recording is saved even if the TUI failed, as those are the
+	// sessions worth replaying.
+	tuiErr := runTUI(app)
+	if recorder != nil {
+		if err := recorder.Save(*recordPath); err != nil {
+			fmt.Fprintf(os.Stderr, "error saving recording: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Recording saved to %s\n", *recordPath)
+	}
+	if tuiErr != nil {
+		fmt.Fprintf(os.Stderr, "gitdeck error: %v\n", tuiErr)
 		os.Exit(1)
 	}
 }
 
-// isGitLabRemote returns true if the remote URL points to gitlab.com or the configured self-hosted URL.
-func isGitLabRemote(remoteURL string, configuredURL string) bool {
-	if strings.Contains(remoteURL, "gitlab.com") {
-		return true
+// newApp creates the TUI for repo with the settings from cfg.
+func newApp(repo domain.Repository, ciProvider domain.PipelineProvider, cfg config.Config, cwd string) (tui.AppModel, error) {
+	app := tui.NewAppModel(repo, ciProvider)
+	app.DownloadDir = cfg.DownloadDir
+	if app.DownloadDir == "" {
+		app.DownloadDir = cwd
+	}
+	logRules, err := compileLogRules(cfg.LogRules)
+	if err != nil {
+		return tui.AppModel{}, err
+	}
+	app.LogAnalyzer = logscan.NewAnalyzer(logRules)
+	if cfg.Network.InsecureSkipVerify {
+		app.Warning = "network.insecure_skip_verify is set: TLS certificates are not verified and connections can be intercepted"
+	}
+	return app, nil
+}
+
+// runTUI runs app until the user quits.
+func runTUI(app tui.AppModel) error {
+	p := tea.NewProgram(app, tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
+
+// gitLabInstance is a GitLab server from the configuration, with the adapter
+// and provider created for it.
+type gitLabInstance struct {
+	name     string
+	url      string
+	clientID string
+	// token and refreshToken point into the configuration, so that tokens
+	// obtained by authenticating are saved with it.
+	token        *string
+	refreshToken *string
+	adapter      *gitlabprovider.Adapter
+	provider     *provider.RefreshingProvider
+}
+
+// newGitLabInstances creates an adapter, a token manager and a refreshing
+// provider for the [gitlab] section and each [[gitlab.instances]] entry,
+// in that order. cacheDir, if not empty, enables the on-disk response cache.
+func newGitLabInstances(cfg *config.Config, configPath string, limit int, httpClient *http.Client, cacheDir string) ([]*gitLabInstance, error) {
+	instances := []*gitLabInstance{{
+		url:          cfg.GitLab.URL,
+		clientID:     cfg.GitLab.ClientID,
+		token:        &cfg.GitLab.Token,
+		refreshToken: &cfg.GitLab.RefreshToken,
+	}}
+	for i := range cfg.GitLab.Instances {
+		ic := &cfg.GitLab.Instances[i]
+		if ic.URL == "" {
+			return nil, fmt.Errorf("gitlab.instances[%d]: url is required", i)
+		}
+		instances = append(instances, &gitLabInstance{
+			name:         ic.Name,
+			url:          ic.URL,
+			clientID:     ic.ClientID,
+			token:        &ic.Token,
+			refreshToken: &ic.RefreshToken,
+		})
+	}
+	for i, gl := range instances {
+		adapter := gitlabprovider.NewAdapter(*gl.token, gl.url, limit)
+		adapter.SetHTTPClient(httpClient)
+		if cacheDir != "" {
+			// Responses are cached by URL, so the instances can share a directory.
+			adapter.SetCache(apiclient.NewDiskCache(filepath.Join(cacheDir, "gitlab")))
+		}
+		// Create token manager for silent refresh
+		var tokenManager *auth.TokenManager
+		if i == 0 {
+			tokenManager = auth.NewTokenManager(cfg, configPath, gl.url)
+		} else {
+			tokenManager = auth.NewGitLabInstanceTokenManager(cfg, configPath, i-1)
+		}
+		tokenManager.SetHTTPClient(httpClient)
+		gl.adapter = adapter
+		gl.provider = provider.NewRefreshingProvider(
+			adapter, "gitlab",
+			func() (string, error) { return tokenManager.RefreshGitLab(context.Background()) },
+			func(token string) { adapter.SetToken(token) },
+		)
+	}
+	return instances, nil
+}
+
+// networkOptions converts the [network] configuration to HTTP client options.
+func networkOptions(nc config.NetworkConfig) network.Options {
+	return network.Options{
+		ProxyURL:           nc.Proxy,
+		CABundle:           nc.CABundle,
+		ClientCert:         nc.ClientCert,
+		ClientKey:          nc.ClientKey,
+		Timeout:            nc.Timeout,
+		InsecureSkipVerify: nc.InsecureSkipVerify,
+	}
+}
+
+// compileLogRules returns the configured log rules followed by the built-in ones,
+// so that user rules take precedence when both match a line.
+func compileLogRules(configured []config.LogRuleConfig) ([]logscan.Rule, error) {
+	var rules []logscan.Rule
+	for _, rc := range configured {
+		rule, err := logscan.CompileRule(rc.Name, rc.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
 	}
-	return configuredURL != "" && strings.Contains(remoteURL, configuredURL)
+	return append(rules, logscan.DefaultRules()...), nil
 }
 
 // runGitHubAuth runs the GitHub Device Authorization Flow interactively.
 // All prompts are written to stderr so stdout remains clean for piping.
 // It blocks until the user completes authorization or an error occurs.
-func runGitHubAuth(ctx context.Context, clientID string) (auth.TokenResponse, error) {
+func runGitHubAuth(ctx context.Context, clientID string, httpClient *http.Client) (auth.TokenResponse, error) {
 	if clientID == "" {
 		clientID = defaultGitHubClientID
 	}
 	flow := auth.NewGitHubDeviceFlow(clientID, "")
+	flow.SetHTTPClient(httpClient)
 	code, err := flow.RequestCode(ctx)
 	if err != nil {
 		return auth.TokenResponse{}, fmt.Errorf("requesting device code: %w", err)
@@ -217,11 +427,12 @@ func runGitHubAuth(ctx context.Context, clientID string) (auth.TokenResponse, er
 // runGitLabAuth runs the GitLab Device Authorization Flow interactively.
 // All prompts are written to stderr so stdout remains clean for piping.
 // baseURL is the GitLab instance base URL; pass empty string for gitlab.com.
-func runGitLabAuth(ctx context.Context, clientID string, baseURL string) (auth.TokenResponse, error) {
+func runGitLabAuth(ctx context.Context, clientID string, baseURL string, httpClient *http.Client) (auth.TokenResponse, error) {
 	if clientID == "" {
 		clientID = defaultGitLabClientID
 	}
 	flow := auth.NewGitLabDeviceFlow(clientID, baseURL)
+	flow.SetHTTPClient(httpClient)
 	code, err := flow.RequestCode(ctx)
 	if err != nil {
 		return auth.TokenResponse{}, fmt.Errorf("requesting device code: %w", err)
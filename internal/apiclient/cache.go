@@ -0,0 +1,199 @@
+package apiclient
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxMemoryEntries bounds the responses kept in memory; the least
+	// recently used ones are evicted first. Polled lists and details stay in,
+	// while one-off lookups such as per-commit details age out.
+	maxMemoryEntries = 512
+	// maxDiskEntries and maxDiskAge bound the on-disk cache, which is pruned
+	// when it is opened.
+	maxDiskEntries = 2048
+	maxDiskAge     = 7 * 24 * time.Hour
+)
+
+// CachedResponse is a response body with the validators needed to revalidate
+// it and, for a page of a listing, the URL of the next page.
+type CachedResponse struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Next         string `json:"next,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// Cache stores responses for conditional requests. Keys are URLs prefixed
+// with a fingerprint of the credentials they were fetched with.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(url string) (CachedResponse, bool)
+	Set(url string, resp CachedResponse)
+}
+
+// MemoryCache is a Cache that lives for the lifetime of the process and
+// holds at most maxMemoryEntries responses.
+type MemoryCache struct {
+	mu      sync.Mutex
+	max     int
+	order   *list.List // of *memoryEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	url  string
+	resp CachedResponse
+}
+
+// NewMemoryCache creates an empty in-memory cache.
+func NewMemoryCache() *MemoryCache {
+	return newMemoryCache(maxMemoryEntries)
+}
+
+func newMemoryCache(max int) *MemoryCache {
+	return &MemoryCache{max: max, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Get returns the cached response for url.
+func (m *MemoryCache) Get(url string) (CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.entries[url]
+	if !ok {
+		return CachedResponse{}, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*memoryEntry).resp, true
+}
+
+// Set stores the response for url, evicting the least recently used
+// response if the cache is full.
+func (m *MemoryCache) Set(url string, resp CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.entries[url]; ok {
+		elem.Value.(*memoryEntry).resp = resp
+		m.order.MoveToFront(elem)
+		return
+	}
+	m.entries[url] = m.order.PushFront(&memoryEntry{url: url, resp: resp})
+	if m.order.Len() > m.max {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).url)
+	}
+}
+
+// Len returns the number of cached responses.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// DiskCache is a Cache persisted to a directory, so that the first refresh
+// after a restart can be revalidated instead of refetched. Entries are kept
+// in memory as well; disk errors are ignored since the cache is best effort.
+// Files are readable by the owner only, as they hold API responses.
+type DiskCache struct {
+	dir    string
+	memory *MemoryCache
+}
+
+// NewDiskCache creates a cache storing one file per URL in dir. Entries
+// older than maxDiskAge, and the oldest beyond maxDiskEntries, are removed.
+func NewDiskCache(dir string) *DiskCache {
+	pruneDir(dir, maxDiskEntries, maxDiskAge)
+	return &DiskCache{dir: dir, memory: NewMemoryCache()}
+}
+
+// pruneDir removes the cache entries in dir that are older than maxAge and
+// the least recently written ones beyond max.
+func pruneDir(dir string, max int, maxAge time.Duration) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	type file struct {
+		path    string
+		modTime time.Time
+	}
+	var files []file
+	for _, e := range dirEntries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, file{path: filepath.Join(dir, e.Name()), modTime: info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+	cutoff := time.Now().Add(-maxAge)
+	for i, f := range files {
+		if i >= max || f.modTime.Before(cutoff) {
+			os.Remove(f.path)
+		}
+	}
+}
+
+// Get returns the cached response for url, reading it from disk on first use.
+func (d *DiskCache) Get(url string) (CachedResponse, bool) {
+	if resp, ok := d.memory.Get(url); ok {
+		return resp, true
+	}
+	data, err := os.ReadFile(d.path(url))
+	if err != nil {
+		return CachedResponse{}, false
+	}
+	var resp CachedResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return CachedResponse{}, false
+	}
+	d.memory.Set(url, resp)
+	return resp, true
+}
+
+// Set stores the response for url in memory and on disk.
+func (d *DiskCache) Set(url string, resp CachedResponse) {
+	d.memory.Set(url, resp)
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(d.dir, 0700); err != nil {
+		return
+	}
+	// Write to a temporary file first so that concurrent readers never see
+	// a partially written entry.
+	tmp, err := os.CreateTemp(d.dir, "entry-*")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), d.path(url)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// path returns the file an entry is stored in, named after the URL's hash.
+func (d *DiskCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,553 @@
+// Package apiclient implements the HTTP layer shared by the provider adapters:
+// authentication, error mapping and rate-limit handling.
+package apiclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+const (
+	// defaultTimeout bounds API requests. Downloads are not bounded.
+	defaultTimeout = 15 * time.Second
+	// maxWait is the longest a request waits for a rate limit to reset before
+	// being sent; longer waits fail fast with domain.RateLimitedError.
+	maxWait = 2 * time.Second
+	// defaultBackoff is how long requests are held back after being rate
+	// limited when the API does not say when to retry.
+	defaultBackoff = time.Minute
+	// maxRetries is how often a GET is retried after a transient failure,
+	// waiting retryBase, then twice as long, and so on, with jitter.
+	maxRetries = 3
+	retryBase  = 250 * time.Millisecond
+	// maxErrorBody bounds how much of an error response is read for its message.
+	maxErrorBody = 64 << 10
+	// maxPages bounds how many pages GetAll follows, so a runaway listing
+	// cannot exhaust the rate limit.
+	maxPages = 20
+)
+
+// Client performs authenticated requests against a provider's REST API.
+// It tracks the rate-limit headers of every response: once the quota is
+// exhausted or the API asks to back off, requests are held back until the
+// limit resets instead of being sent. JSON responses are cached by URL and
+// revalidated with conditional requests, so polling unchanged resources
+// costs 304 responses, which GitHub does not count against the rate limit.
+// It is safe for concurrent use.
+type Client struct {
+	name    string
+	headers map[string]string
+	client  *http.Client
+	cache   Cache
+
+	mu           sync.Mutex
+	token        string
+	rateLimit    domain.RateLimit
+	hasRateLimit bool
+	blockedUntil time.Time
+}
+
+// New creates a client for the named provider. name prefixes error messages,
+// e.g. "github API error: 404 Not Found". headers are sent with every request
+// in addition to the bearer token.
+func New(name, token string, headers map[string]string) *Client {
+	return &Client{
+		name:    name,
+		headers: headers,
+		client:  &http.Client{Timeout: defaultTimeout},
+		cache:   NewMemoryCache(),
+		token:   token,
+	}
+}
+
+// SetCache replaces the response cache, e.g. with a DiskCache that survives
+// restarts. It must be called before the client is used.
+func (c *Client) SetCache(cache Cache) {
+	c.cache = cache
+}
+
+// SetHTTPClient replaces the HTTP client, e.g. with one configured for a
+// proxy or a custom CA. It must be called before the client is used.
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.client = client
+}
+
+// SetToken updates the access token used for requests.
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// RateLimit returns the quota reported by the last response that carried
+// rate-limit headers. Returns false until one has been seen.
+func (c *Client) RateLimit() (domain.RateLimit, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit, c.hasRateLimit
+}
+
+// Get fetches url and decodes the JSON response into target.
+func (c *Client) Get(url string, target interface{}) error {
+	_, err := c.GetPage(url, target)
+	return err
+}
+
+// GetPage works like Get and also returns the URL of the next page of a
+// paginated listing, or "" on the last page. The next page is read from the
+// Link header's rel="next" or, failing that, from GitLab's X-Next-Page.
+func (c *Client) GetPage(url string, target interface{}) (string, error) {
+	resp, _, err := c.getCached(url, false)
+	if err != nil {
+		return "", err
+	}
+	return resp.Next, json.Unmarshal(resp.Body, target)
+}
+
+// GetAll fetches url and the pages that follow it, up to maxPages, and
+// returns the items of all of them. Callers should ask for the largest page
+// size the API allows.
+func GetAll[T any](c *Client, url string) ([]T, error) {
+	return GetAllWrapped(c, url, func(items []T) []T { return items })
+}
+
+// GetAllWrapped works like GetAll for APIs that wrap the items of each page
+// in an object, such as GitHub's {"total_count": 1, "artifacts": [...]}.
+// items extracts them from a decoded page.
+func GetAllWrapped[W, T any](c *Client, url string, items func(W) []T) ([]T, error) {
+	var all []T
+	for i := 0; i < maxPages && url != ""; i++ {
+		var page W
+		next, err := c.GetPage(url, &page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items(page)...)
+		url = next
+	}
+	return all, nil
+}
+
+// GetIfExists works like Get but reports a 404 as found=false instead of an error.
+// It is used for optional resources such as report files inside job artifacts.
+func (c *Client) GetIfExists(url string, target interface{}) (bool, error) {
+	resp, found, err := c.getCached(url, true)
+	if err != nil || !found {
+		return false, err
+	}
+	return true, json.Unmarshal(resp.Body, target)
+}
+
+// getCached fetches url, revalidating a cached response if there is one.
+// If allowMissing is set, a 404 is reported as found=false instead of an error.
+func (c *Client) getCached(url string, allowMissing bool) (CachedResponse, bool, error) {
+	key := c.cacheKey(url)
+	cached, hasCached := c.cache.Get(key)
+	var conditional map[string]string
+	if hasCached {
+		conditional = make(map[string]string)
+		if cached.ETag != "" {
+			conditional["If-None-Match"] = cached.ETag
+		}
+		if cached.LastModified != "" {
+			conditional["If-Modified-Since"] = cached.LastModified
+		}
+	}
+	resp, err := c.do(http.MethodGet, url, c.client, conditional)
+	if err != nil {
+		return CachedResponse{}, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		return cached, true, nil
+	}
+	if allowMissing && resp.StatusCode == http.StatusNotFound {
+		return CachedResponse{}, false, nil
+	}
+	if err := c.checkStatus(resp); err != nil {
+		return CachedResponse{}, false, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return CachedResponse{}, false, fmt.Errorf("reading response: %w", err)
+	}
+	fetched := CachedResponse{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Next:         nextPage(url, resp.Header),
+		Body:         body,
+	}
+	if fetched.ETag != "" || fetched.LastModified != "" {
+		c.cache.Set(key, fetched)
+	}
+	return fetched, true, nil
+}
+
+// nextPage returns the URL of the page after current, or "" if there is
+// none. A Link header pointing at another host is not followed, since the
+// token would be sent along; GitLab behind a misconfigured proxy produces
+// such links, and its X-Next-Page is used instead.
+func nextPage(current string, h http.Header) string {
+	base, err := neturl.Parse(current)
+	if err != nil {
+		return ""
+	}
+	for _, link := range strings.Split(h.Get("Link"), ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		next, err := base.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err == nil && next.Scheme == base.Scheme && next.Host == base.Host {
+			return next.String()
+		}
+	}
+	if page := h.Get("X-Next-Page"); page != "" {
+		query := base.Query()
+		query.Set("page", page)
+		base.RawQuery = query.Encode()
+		return base.String()
+	}
+	return ""
+}
+
+// cacheKey returns the key url is cached under: the URL prefixed with a
+// fingerprint of the token, so that a response fetched with one account's
+// credentials is never served to another.
+func (c *Client) cacheKey(url string) string {
+	c.mu.Lock()
+	token := c.token
+	c.mu.Unlock()
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8]) + " " + url
+}
+
+// GetText fetches url and returns the response body as a plain string.
+// Redirects follow Go's default policy, which strips the Authorization header
+// on cross-domain redirects, such as GitHub's redirect of log downloads to a
+// pre-signed storage URL.
+func (c *Client) GetText(url string) (string, error) {
+	resp, err := c.do(http.MethodGet, url, c.client, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := c.checkStatus(resp); err != nil {
+		return "", err
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading log response: %w", err)
+	}
+	return string(b), nil
+}
+
+// Download fetches url and streams the response body to dst, reporting
+// progress after every chunk. total is the expected size, replaced by the
+// response's Content-Length when known.
+func (c *Client) Download(url string, dst io.Writer, total int64, progress func(written, total int64)) error {
+	// Archives can be far larger than API responses, so the client timeout does not apply.
+	client := *c.client
+	client.Timeout = 0
+	resp, err := c.do(http.MethodGet, url, &client, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := c.checkStatus(resp); err != nil {
+		return err
+	}
+	if resp.ContentLength > 0 {
+		total = resp.ContentLength
+	}
+	if _, err := io.Copy(dst, &progressReader{r: resp.Body, total: total, progress: progress}); err != nil {
+		return fmt.Errorf("downloading artifact: %w", err)
+	}
+	return nil
+}
+
+// Post sends a POST request without a body and discards the response.
+func (c *Client) Post(url string) error {
+	return c.send(http.MethodPost, url)
+}
+
+// Put sends a PUT request without a body and discards the response.
+func (c *Client) Put(url string) error {
+	return c.send(http.MethodPut, url)
+}
+
+func (c *Client) send(method, url string) error {
+	resp, err := c.do(method, url, c.client, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return c.checkStatus(resp)
+}
+
+// do sends an authenticated request with the given extra headers and records
+// the rate limit reported by the response. Rate-limited responses are returned
+// as domain.RateLimitedError; GET requests are retried once when the limit
+// resets within maxWait. Since GET requests are idempotent, they are also
+// retried with exponential backoff after gateway errors and dropped
+// connections; failures that persist are returned as domain.TransientError.
+func (c *Client) do(method, url string, client *http.Client, headers map[string]string) (*http.Response, error) {
+	retries, waitedForLimit := 0, false
+	for {
+		if err := c.waitForRateLimit(); err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		token := c.token
+		c.mu.Unlock()
+
+		req, err := http.NewRequest(method, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		for k, v := range c.headers {
+			req.Header.Set(k, v)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			err = fmt.Errorf("executing request: %w", err)
+			if isTimeout(err) {
+				// The request already took the whole timeout; retrying would
+				// stall the caller for several times as long.
+				return nil, &domain.TransientError{Err: err}
+			}
+			if !isConnectionError(err) {
+				return nil, err
+			}
+			if method != http.MethodGet || retries == maxRetries {
+				return nil, &domain.TransientError{Err: err}
+			}
+			retries++
+			time.Sleep(retryDelay(retries))
+			continue
+		}
+		if isGatewayError(resp.StatusCode) && method == http.MethodGet && retries < maxRetries {
+			resp.Body.Close()
+			retries++
+			time.Sleep(retryDelay(retries))
+			continue
+		}
+		retryAt, limited := c.record(resp)
+		if !limited {
+			return resp, nil
+		}
+		resp.Body.Close()
+		if method != http.MethodGet || waitedForLimit || time.Until(retryAt) > maxWait {
+			return nil, &domain.RateLimitedError{Provider: c.name, RetryAt: retryAt}
+		}
+		waitedForLimit = true
+	}
+}
+
+// waitForRateLimit holds a request back while the client is backing off.
+// Short waits are slept through; longer ones fail fast.
+func (c *Client) waitForRateLimit() error {
+	c.mu.Lock()
+	until := c.blockedUntil
+	c.mu.Unlock()
+	wait := time.Until(until)
+	if wait <= 0 {
+		return nil
+	}
+	if wait > maxWait {
+		return &domain.RateLimitedError{Provider: c.name, RetryAt: until}
+	}
+	time.Sleep(wait)
+	return nil
+}
+
+// record stores the rate limit reported by resp and starts backing off when
+// the quota is exhausted. It reports whether the request itself was rejected
+// for exceeding the rate limit, and when it may be retried.
+func (c *Client) record(resp *http.Response) (time.Time, bool) {
+	now := time.Now()
+	remaining, hasRemaining := headerInt(resp.Header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	limit, _ := headerInt(resp.Header, "X-RateLimit-Limit", "RateLimit-Limit")
+	var resetAt time.Time
+	if reset, ok := headerInt(resp.Header, "X-RateLimit-Reset", "RateLimit-Reset"); ok {
+		resetAt = time.Unix(int64(reset), 0)
+	}
+	retryAfter, hasRetryAfter := headerInt(resp.Header, "Retry-After")
+
+	// GitHub reports primary and secondary rate limits as 403 or 429;
+	// GitLab uses 429.
+	limited := resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && (hasRetryAfter || (hasRemaining && remaining == 0)))
+
+	var retryAt time.Time
+	switch {
+	case hasRetryAfter:
+		retryAt = now.Add(time.Duration(retryAfter) * time.Second)
+	case hasRemaining && remaining == 0 && !resetAt.IsZero():
+		retryAt = resetAt
+	case limited:
+		retryAt = now.Add(defaultBackoff)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if hasRemaining {
+		c.rateLimit = domain.RateLimit{Limit: limit, Remaining: remaining, ResetAt: resetAt}
+		c.hasRateLimit = true
+	}
+	if retryAt.After(c.blockedUntil) {
+		c.blockedUntil = retryAt
+	}
+	return retryAt, limited
+}
+
+// checkStatus maps error responses to domain.APIError, wrapping the sentinel
+// for the status: domain.ErrUnauthorized, ErrForbidden, ErrNotFound or
+// ErrConflict. Gateway errors are wrapped in domain.TransientError.
+func (c *Client) checkStatus(resp *http.Response) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	apiErr := &domain.APIError{Provider: c.name, Status: resp.Status, Message: errorMessage(resp.Body)}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		apiErr.Err = domain.ErrUnauthorized
+	case http.StatusForbidden:
+		apiErr.Err = domain.ErrForbidden
+	case http.StatusNotFound:
+		apiErr.Err = domain.ErrNotFound
+	case http.StatusConflict:
+		apiErr.Err = domain.ErrConflict
+	}
+	if isGatewayError(resp.StatusCode) {
+		return &domain.TransientError{Err: apiErr}
+	}
+	return apiErr
+}
+
+// errorMessage extracts the explanation from an error response body.
+// GitHub sends {"message": "..."}; GitLab sends "message" as a string, a
+// list or a map of field errors, or "error" with "error_description".
+func errorMessage(body io.Reader) string {
+	var payload struct {
+		Message          interface{} `json:"message"`
+		Error            string      `json:"error"`
+		ErrorDescription string      `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(body, maxErrorBody)).Decode(&payload); err != nil {
+		return ""
+	}
+	switch {
+	case payload.Message != nil:
+		return flattenMessage(payload.Message)
+	case payload.ErrorDescription != "":
+		return payload.ErrorDescription
+	default:
+		return payload.Error
+	}
+}
+
+// flattenMessage renders a GitLab message, which may be nested, on one line.
+func flattenMessage(v interface{}) string {
+	switch msg := v.(type) {
+	case string:
+		return msg
+	case []interface{}:
+		parts := make([]string, 0, len(msg))
+		for _, item := range msg {
+			parts = append(parts, flattenMessage(item))
+		}
+		return strings.Join(parts, "; ")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(msg))
+		for k := range msg {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, k+" "+flattenMessage(msg[k]))
+		}
+		return strings.Join(parts, "; ")
+	default:
+		return fmt.Sprint(msg)
+	}
+}
+
+// isGatewayError reports whether status means the API is temporarily
+// unreachable rather than that the request was wrong.
+func isGatewayError(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+// isTimeout reports whether err is a request that timed out.
+func isTimeout(err error) bool {
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// isConnectionError reports whether err is a dropped or refused connection,
+// which is worth retrying.
+func isConnectionError(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryDelay returns the wait before the given retry: retryBase doubled per
+// retry, with up to half of it randomized so clients don't retry in lockstep.
+func retryDelay(retry int) time.Duration {
+	d := retryBase << (retry - 1)
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// headerInt returns the integer value of the first of names present in h.
+func headerInt(h http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		if v := h.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, false
+			}
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// progressReader wraps a reader and reports the running byte count after every read.
+type progressReader struct {
+	r        io.Reader
+	written  int64
+	total    int64
+	progress func(written, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.written += int64(n)
+	if n > 0 && p.progress != nil {
+		p.progress(p.written, p.total)
+	}
+	return n, err
+}
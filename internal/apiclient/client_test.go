@@ -0,0 +1,490 @@
+package apiclient_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/apiclient"
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+func TestGet_RecordsRateLimitHeaders(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" || r.Header.Get("Accept") != "application/vnd.github+json" {
+			http.Error(w, "missing headers", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("github", "test-token", map[string]string{"Accept": "application/vnd.github+json"})
+	if _, ok := client.RateLimit(); ok {
+		t.Error("expected no rate limit before the first response")
+	}
+	var result struct{ ID int }
+	if err := client.Get(srv.URL, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	limit, ok := client.RateLimit()
+	if !ok || limit.Limit != 5000 || limit.Remaining != 4321 || !limit.ResetAt.Equal(reset) {
+		t.Errorf("unexpected rate limit: %+v", limit)
+	}
+}
+
+func TestGet_ReadsGitLabRateLimitHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Limit", "2000")
+		w.Header().Set("RateLimit-Remaining", "1999")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("gitlab", "test-token", nil)
+	var result []interface{}
+	if err := client.Get(srv.URL, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limit, ok := client.RateLimit(); !ok || limit.Remaining != 1999 {
+		t.Errorf("unexpected rate limit: %+v", limit)
+	}
+}
+
+func TestGet_UnauthorizedWrapsErrUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	err := apiclient.New("gitlab", "expired", nil).Get(srv.URL, &struct{}{})
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestGet_RateLimitedBacksOffWithoutSendingRequests(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("github", "test-token", nil)
+	err := client.Get(srv.URL, &struct{}{})
+	var rateErr *domain.RateLimitedError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("expected RateLimitedError, got %v", err)
+	}
+	if wait := time.Until(rateErr.RetryAt); wait < 55*time.Second || wait > 61*time.Second {
+		t.Errorf("expected retry in about a minute, got %s", wait)
+	}
+
+	if err := client.Get(srv.URL, &struct{}{}); !errors.As(err, &rateErr) {
+		t.Errorf("expected the next request to fail fast, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected requests to be held back while rate limited, got %d calls", calls)
+	}
+}
+
+func TestGet_ExhaustedQuotaWaitsForReset(t *testing.T) {
+	reset := time.Now().Add(10 * time.Minute)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("github", "test-token", nil)
+	if err := client.Get(srv.URL, &struct{}{}); err != nil {
+		t.Fatalf("expected the last request of the quota to succeed, got %v", err)
+	}
+	var rateErr *domain.RateLimitedError
+	if err := client.Get(srv.URL, &struct{}{}); !errors.As(err, &rateErr) || rateErr.RetryAt.Unix() != reset.Unix() {
+		t.Errorf("expected to wait for the quota reset, got %v", err)
+	}
+}
+
+func TestGet_RetriesAfterShortRetryAfter(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	if err := apiclient.New("gitlab", "test-token", nil).Get(srv.URL, &struct{}{}); err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestGetIfExists_ReportsNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	found, err := apiclient.New("gitlab", "test-token", nil).GetIfExists(srv.URL, &struct{}{})
+	if err != nil || found {
+		t.Errorf("expected not found without error, got found=%v err=%v", found, err)
+	}
+}
+
+func TestGet_RevalidatesCachedResponseWithETag(t *testing.T) {
+	var requests, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id": 7}`))
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("github", "test-token", nil)
+	for i := 0; i < 3; i++ {
+		var result struct{ ID int }
+		if err := client.Get(srv.URL, &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != 7 {
+			t.Errorf("request %d: expected cached ID 7, got %d", i, result.ID)
+		}
+	}
+	if requests != 3 || notModified != 2 {
+		t.Errorf("expected 3 requests with 2 revalidated, got %d and %d", requests, notModified)
+	}
+}
+
+func TestGet_SendsIfModifiedSince(t *testing.T) {
+	const lastModified = "Mon, 02 Jan 2006 15:04:05 GMT"
+	var conditional string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = r.Header.Get("If-Modified-Since")
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("gitlab", "test-token", nil)
+	var result []interface{}
+	for i := 0; i < 2; i++ {
+		if err := client.Get(srv.URL, &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if conditional != lastModified {
+		t.Errorf("expected If-Modified-Since %q, got %q", lastModified, conditional)
+	}
+}
+
+func TestGetAll_FollowsLinkHeaderAcrossCachedPages(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page := r.URL.Query().Get("page")
+		etag := `"page-` + page + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		switch page {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/items?page=2>; rel="next", <http://%s/items?page=2>; rel="last"`, r.Host, r.Host))
+			w.Write([]byte(`[1, 2]`))
+		case "2":
+			w.Write([]byte(`[3]`))
+		}
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("github", "test-token", nil)
+	for i := 0; i < 2; i++ {
+		items, err := apiclient.GetAll[int](client, srv.URL+"/items")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(items) != 3 || items[2] != 3 {
+			t.Errorf("listing %d: expected items of both pages, got %v", i, items)
+		}
+	}
+	if requests != 4 {
+		t.Errorf("expected each page requested once per listing, got %d requests", requests)
+	}
+}
+
+func TestGetAll_FallsBackToXNextPageForForeignLinks(t *testing.T) {
+	var pages []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("page"))
+		if r.URL.Query().Get("page") == "2" {
+			w.Header().Set("X-Next-Page", "")
+			w.Write([]byte(`[3]`))
+			return
+		}
+		// GitLab behind a proxy may link to its internal host.
+		w.Header().Set("Link", `<http://gitlab.internal/items?page=2&per_page=2>; rel="next"`)
+		w.Header().Set("X-Next-Page", "2")
+		w.Write([]byte(`[1, 2]`))
+	}))
+	defer srv.Close()
+
+	items, err := apiclient.GetAll[int](apiclient.New("gitlab", "test-token", nil), srv.URL+"/items?per_page=2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 3 {
+		t.Errorf("expected items of both pages, got %v", items)
+	}
+	if len(pages) != 2 || pages[1] != "2" {
+		t.Errorf("expected the second page read from the API host, got pages %q", pages)
+	}
+}
+
+func TestDiskCache_SurvivesNewClient(t *testing.T) {
+	dir := t.TempDir()
+	var revalidated bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidated = true
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id": 7}`))
+	}))
+	defer srv.Close()
+
+	first := apiclient.New("github", "test-token", nil)
+	first.SetCache(apiclient.NewDiskCache(dir))
+	var result struct{ ID int }
+	if err := first.Get(srv.URL, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second := apiclient.New("github", "test-token", nil)
+	second.SetCache(apiclient.NewDiskCache(dir))
+	result.ID = 0
+	if err := second.Get(srv.URL, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !revalidated || result.ID != 7 {
+		t.Errorf("expected revalidated cached response, got revalidated=%v id=%d", revalidated, result.ID)
+	}
+}
+
+func TestDiskCache_DoesNotServeResponsesAcrossTokens(t *testing.T) {
+	dir := t.TempDir()
+	var revalidated bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			revalidated = true
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id": 7}`))
+	}))
+	defer srv.Close()
+
+	first := apiclient.New("github", "alice-token", nil)
+	first.SetCache(apiclient.NewDiskCache(dir))
+	var result struct{ ID int }
+	if err := first.Get(srv.URL, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second := apiclient.New("github", "bob-token", nil)
+	second.SetCache(apiclient.NewDiskCache(dir))
+	if err := second.Get(srv.URL, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.SetToken("alice-new-token")
+	if err := first.Get(srv.URL, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revalidated {
+		t.Error("expected a response cached under another token not to be revalidated")
+	}
+}
+
+func TestDiskCache_PrunesOldEntries(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "stale.json")
+	fresh := filepath.Join(dir, "fresh.json")
+	for _, path := range []string{stale, fresh} {
+		if err := os.WriteFile(path, []byte(`{"body":"e30="}`), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	apiclient.NewDiskCache(dir)
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("expected the month-old entry to be removed")
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Errorf("expected the fresh entry to be kept: %v", err)
+	}
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := apiclient.NewMemoryCache()
+	cache.Set("first", apiclient.CachedResponse{ETag: "1"})
+	for i := 0; i < 1000; i++ {
+		cache.Set(fmt.Sprintf("url-%d", i), apiclient.CachedResponse{})
+		// Keep the first entry in use, as a polled list would be.
+		cache.Get("first")
+	}
+	if cache.Len() != 512 {
+		t.Errorf("expected the cache to be bounded to 512 entries, got %d", cache.Len())
+	}
+	if _, ok := cache.Get("first"); !ok {
+		t.Error("expected the entry in use to be kept")
+	}
+	if _, ok := cache.Get("url-0"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+}
+
+func TestGet_RetriesGatewayErrors(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("github", "test-token", nil)
+	var result struct{ ID int }
+	if err := client.Get(srv.URL, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 3 || result.ID != 1 {
+		t.Errorf("expected success on the third request, got %d requests and id %d", requests, result.ID)
+	}
+}
+
+func TestGet_PersistentGatewayErrorIsTransient(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("gitlab", "test-token", nil)
+	var result struct{}
+	err := client.Get(srv.URL, &result)
+	if !domain.IsTransient(err) {
+		t.Fatalf("expected a transient error, got %v", err)
+	}
+	if requests != 4 {
+		t.Errorf("expected 1 request and 3 retries, got %d requests", requests)
+	}
+}
+
+func TestGet_RetriesDroppedConnections(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("github", "test-token", nil)
+	var result struct{ ID int }
+	if err := client.Get(srv.URL, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected one retry, got %d requests", requests)
+	}
+}
+
+func TestPost_DoesNotRetryGatewayErrors(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	client := apiclient.New("github", "test-token", nil)
+	if err := client.Post(srv.URL); !domain.IsTransient(err) {
+		t.Errorf("expected a transient error, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected no retries for POST, got %d requests", requests)
+	}
+}
+
+func TestGet_MapsStatusToTypedErrors(t *testing.T) {
+	cases := []struct {
+		status  int
+		body    string
+		want    error
+		message string
+	}{
+		{http.StatusUnauthorized, `{"message": "Bad credentials"}`, domain.ErrUnauthorized, "Bad credentials"},
+		{http.StatusForbidden, `{"message": "Resource not accessible by integration"}`, domain.ErrForbidden, "Resource not accessible by integration"},
+		{http.StatusNotFound, `{"message": "404 Project Not Found"}`, domain.ErrNotFound, "404 Project Not Found"},
+		{http.StatusConflict, `{"message": {"base": ["Pipeline cannot be canceled"]}}`, domain.ErrConflict, "base Pipeline cannot be canceled"},
+		{http.StatusForbidden, `{"error": "insufficient_scope", "error_description": "The request requires higher privileges."}`, domain.ErrForbidden, "The request requires higher privileges."},
+	}
+	for _, tc := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+			w.Write([]byte(tc.body))
+		}))
+		client := apiclient.New("gitlab", "test-token", nil)
+		var result struct{}
+		err := client.Get(srv.URL, &result)
+		srv.Close()
+
+		var apiErr *domain.APIError
+		if !errors.As(err, &apiErr) || !errors.Is(err, tc.want) {
+			t.Errorf("status %d: expected APIError wrapping %v, got %v", tc.status, tc.want, err)
+			continue
+		}
+		if apiErr.Message != tc.message {
+			t.Errorf("status %d: expected message %q, got %q", tc.status, tc.message, apiErr.Message)
+		}
+	}
+}
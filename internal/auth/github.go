@@ -33,6 +33,12 @@ func NewGitHubDeviceFlow(clientID string, baseURL string) *GitHubDeviceFlow {
 	}
 }
 
+// SetHTTPClient replaces the HTTP client, e.g. with one configured for a
+// proxy or a custom CA.
+func (f *GitHubDeviceFlow) SetHTTPClient(client *http.Client) {
+	f.client = client
+}
+
 // RequestCode requests a device code and user code from GitHub.
 // The returned DeviceCodeResponse.UserCode must be shown to the user along with VerificationURI.
 // ctx is used to cancel the request (e.g. when the user quits the TUI).
@@ -33,6 +33,12 @@ func NewGitLabDeviceFlow(clientID string, baseURL string) *GitLabDeviceFlow {
 	}
 }
 
+// SetHTTPClient replaces the HTTP client, e.g. with one configured for a
+// proxy or a custom CA.
+func (f *GitLabDeviceFlow) SetHTTPClient(client *http.Client) {
+	f.client = client
+}
+
 // RequestCode requests a device code and user code from GitLab.
 // The returned DeviceCodeResponse.UserCode must be shown to the user along with VerificationURI.
 // ctx is used to cancel the request (e.g. when the user quits the TUI).
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 
 	"github.com/waabox/gitdeck/internal/config"
@@ -16,6 +17,10 @@ type TokenManager struct {
 	cfg        *config.Config
 	configPath string
 	gitlabURL  string
+	// instance is the index of the refreshed entry in cfg.GitLab.Instances,
+	// or -1 for the [gitlab] section itself.
+	instance   int
+	httpClient *http.Client
 	mu         sync.Mutex
 }
 
@@ -26,9 +31,27 @@ func NewTokenManager(cfg *config.Config, configPath string, gitlabURL string) *T
 		cfg:        cfg,
 		configPath: configPath,
 		gitlabURL:  gitlabURL,
+		instance:   -1,
 	}
 }
 
+// NewGitLabInstanceTokenManager creates a TokenManager for the GitLab server
+// configured as cfg.GitLab.Instances[index].
+func NewGitLabInstanceTokenManager(cfg *config.Config, configPath string, index int) *TokenManager {
+	return &TokenManager{
+		cfg:        cfg,
+		configPath: configPath,
+		gitlabURL:  cfg.GitLab.Instances[index].URL,
+		instance:   index,
+	}
+}
+
+// SetHTTPClient sets the HTTP client used to refresh tokens, e.g. one
+// configured for a proxy or a custom CA.
+func (tm *TokenManager) SetHTTPClient(client *http.Client) {
+	tm.httpClient = client
+}
+
 // RefreshGitLab attempts to refresh the GitLab access token using the stored refresh token.
 // On success, it updates the config in memory and persists it to disk.
 // Returns the new access token or an error.
@@ -36,23 +59,26 @@ func (tm *TokenManager) RefreshGitLab(ctx context.Context) (string, error) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
-	if tm.cfg.GitLab.RefreshToken == "" {
+	clientID, token, refreshToken := tm.gitLabCredentials()
+	if *refreshToken == "" {
 		return "", fmt.Errorf("no refresh token available")
 	}
 
-	clientID := tm.cfg.GitLab.ClientID
 	if clientID == "" {
 		clientID = defaultGitLabClientID
 	}
 
 	flow := NewGitLabDeviceFlow(clientID, tm.gitlabURL)
-	resp, err := flow.RefreshToken(ctx, tm.cfg.GitLab.RefreshToken)
+	if tm.httpClient != nil {
+		flow.SetHTTPClient(tm.httpClient)
+	}
+	resp, err := flow.RefreshToken(ctx, *refreshToken)
 	if err != nil {
 		return "", fmt.Errorf("refreshing GitLab token: %w", err)
 	}
 
-	tm.cfg.GitLab.Token = resp.AccessToken
-	tm.cfg.GitLab.RefreshToken = resp.RefreshToken
+	*token = resp.AccessToken
+	*refreshToken = resp.RefreshToken
 
 	if tm.configPath != "" {
 		if saveErr := config.Save(tm.configPath, *tm.cfg); saveErr != nil {
@@ -65,6 +91,16 @@ func (tm *TokenManager) RefreshGitLab(ctx context.Context) (string, error) {
 	return resp.AccessToken, nil
 }
 
+// gitLabCredentials returns the OAuth client ID of the managed GitLab server
+// and pointers to its tokens in the config.
+func (tm *TokenManager) gitLabCredentials() (clientID string, token, refreshToken *string) {
+	if tm.instance >= 0 {
+		inst := &tm.cfg.GitLab.Instances[tm.instance]
+		return inst.ClientID, &inst.Token, &inst.RefreshToken
+	}
+	return tm.cfg.GitLab.ClientID, &tm.cfg.GitLab.Token, &tm.cfg.GitLab.RefreshToken
+}
+
 // Config returns the current config pointer.
 func (tm *TokenManager) Config() *config.Config {
 	return tm.cfg
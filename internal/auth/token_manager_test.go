@@ -58,6 +58,53 @@ func TestTokenManager_RefreshGitLab_UpdatesTokensAndSaves(t *testing.T) {
 	}
 }
 
+func TestTokenManager_RefreshGitLab_UpdatesInstanceTokens(t *testing.T) {
+	var gotClientID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotClientID = r.FormValue("client_id")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token":  "new_access",
+			"refresh_token": "new_refresh",
+		})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.toml")
+	cfg := &config.Config{}
+	cfg.GitLab.Token = "primary_access"
+	cfg.GitLab.RefreshToken = "primary_refresh"
+	cfg.GitLab.Instances = []config.GitLabInstanceConfig{
+		{URL: "https://other.example.com", Token: "other_access", RefreshToken: "other_refresh"},
+		{URL: server.URL, ClientID: "corp_client", Token: "old_access", RefreshToken: "old_refresh"},
+	}
+
+	tm := auth.NewGitLabInstanceTokenManager(cfg, cfgPath, 1)
+
+	if _, err := tm.RefreshGitLab(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotClientID != "corp_client" {
+		t.Errorf("expected the instance client ID, got %q", gotClientID)
+	}
+	if cfg.GitLab.Instances[1].Token != "new_access" || cfg.GitLab.Instances[1].RefreshToken != "new_refresh" {
+		t.Errorf("expected instance tokens updated, got %+v", cfg.GitLab.Instances[1])
+	}
+	if cfg.GitLab.Token != "primary_access" || cfg.GitLab.Instances[0].Token != "other_access" {
+		t.Errorf("expected other servers' tokens untouched, got %+v", cfg.GitLab)
+	}
+
+	loaded, err := config.LoadFrom(cfgPath)
+	if err != nil {
+		t.Fatalf("loading saved config: %v", err)
+	}
+	if len(loaded.GitLab.Instances) != 2 || loaded.GitLab.Instances[1].Token != "new_access" {
+		t.Errorf("expected persisted instance token 'new_access', got %+v", loaded.GitLab.Instances)
+	}
+}
+
 func TestTokenManager_RefreshGitLab_ReturnsErrorWhenNoRefreshToken(t *testing.T) {
 	cfg := &config.Config{}
 	cfg.GitLab.Token = "old_access"
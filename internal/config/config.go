@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -20,13 +21,67 @@ type GitLabConfig struct {
 	Token        string `toml:"token"`
 	RefreshToken string `toml:"refresh_token"`
 	URL          string `toml:"url"`
+	// Instances are additional GitLab servers, each with its own credentials.
+	Instances []GitLabInstanceConfig `toml:"instances"`
+}
+
+// GitLabInstanceConfig holds the URL and authentication configuration of an
+// additional GitLab server, configured as a [[gitlab.instances]] entry.
+type GitLabInstanceConfig struct {
+	// Name optionally identifies the instance in [[remotes]] overrides.
+	Name         string `toml:"name"`
+	URL          string `toml:"url"`
+	ClientID     string `toml:"client_id"`
+	Token        string `toml:"token"`
+	RefreshToken string `toml:"refresh_token"`
+}
+
+// NetworkConfig holds settings applied to every HTTP request, to the APIs
+// as well as to the authentication flows.
+type NetworkConfig struct {
+	// Proxy overrides the HTTP_PROXY/HTTPS_PROXY environment variables.
+	Proxy string `toml:"proxy"`
+	// CABundle is a PEM file of additional trusted certificates.
+	CABundle string `toml:"ca_bundle"`
+	// ClientCert and ClientKey are PEM files used for mutual TLS.
+	ClientCert string `toml:"client_cert"`
+	ClientKey  string `toml:"client_key"`
+	// Timeout bounds each request, e.g. "30s". Defaults to 15 seconds.
+	Timeout time.Duration `toml:"timeout"`
+	// InsecureSkipVerify disables TLS certificate verification.
+	InsecureSkipVerify bool `toml:"insecure_skip_verify"`
+}
+
+// LogRuleConfig is a user-defined rule for extracting failures from job logs.
+type LogRuleConfig struct {
+	Name    string `toml:"name"`
+	Pattern string `toml:"pattern"`
+}
+
+// RemoteConfig forces the provider type of the remotes matching Match, a
+// host ("git.corp"), a wildcard ("*.gitlab.corp") or a host and path prefix
+// ("github.com/acme"), instead of detecting it from the host.
+type RemoteConfig struct {
+	Match    string `toml:"match"`
+	Provider string `toml:"provider"`
 }
 
 // Config holds all gitdeck configuration.
 type Config struct {
-	GitHub         GitHubConfig `toml:"github"`
-	GitLab         GitLabConfig `toml:"gitlab"`
-	PipelineLimit  int          `toml:"pipeline_limit"`
+	GitHub        GitHubConfig  `toml:"github"`
+	GitLab        GitLabConfig  `toml:"gitlab"`
+	Network       NetworkConfig `toml:"network"`
+	PipelineLimit int           `toml:"pipeline_limit"`
+	// DownloadDir is the directory proposed when saving artifacts.
+	// Defaults to the current working directory.
+	DownloadDir string `toml:"download_dir"`
+	// LogRules are matched against job logs in addition to the built-in rules.
+	LogRules []LogRuleConfig `toml:"log_rules"`
+	// Remotes override provider detection for matching remotes.
+	Remotes []RemoteConfig `toml:"remotes"`
+	// DiskCache persists API responses under DefaultCacheDir so that the
+	// first refresh after a restart only needs conditional requests.
+	DiskCache bool `toml:"disk_cache"`
 }
 
 const defaultPipelineLimit = 3
@@ -62,6 +117,16 @@ func DefaultConfigPath() string {
 	return home + "/.config/gitdeck/config.toml"
 }
 
+// DefaultCacheDir returns the directory used for the on-disk API response cache.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(dir, "gitdeck", "http")
+}
+
 func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("GITHUB_TOKEN"); v != "" {
 		cfg.GitHub.Token = v
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/waabox/gitdeck/internal/config"
 )
@@ -167,3 +168,151 @@ func TestSave_CreatesParentDirectory(t *testing.T) {
 		t.Errorf("file not created: %v", err)
 	}
 }
+
+func TestLoad_LogRules(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	content := `
+[[log_rules]]
+name = "terraform"
+pattern = '^Error: '
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.LogRules) != 1 {
+		t.Fatalf("expected 1 log rule, got %d", len(cfg.LogRules))
+	}
+	if cfg.LogRules[0].Name != "terraform" || cfg.LogRules[0].Pattern != "^Error: " {
+		t.Errorf("unexpected log rule: %+v", cfg.LogRules[0])
+	}
+}
+
+func TestLoad_GitLabInstances(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	content := `
+[gitlab]
+token = "glpat_public"
+
+[[gitlab.instances]]
+name = "corp"
+url = "https://gitlab.corp.example.com"
+token = "glpat_corp"
+client_id = "corp_app"
+
+[[gitlab.instances]]
+url = "https://gitlab.lab.example.com"
+token = "glpat_lab"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.GitLab.Token != "glpat_public" {
+		t.Errorf("expected the [gitlab] token to be kept, got %q", cfg.GitLab.Token)
+	}
+	if len(cfg.GitLab.Instances) != 2 {
+		t.Fatalf("expected 2 GitLab instances, got %d", len(cfg.GitLab.Instances))
+	}
+	want := config.GitLabInstanceConfig{Name: "corp", URL: "https://gitlab.corp.example.com", Token: "glpat_corp", ClientID: "corp_app"}
+	if cfg.GitLab.Instances[0] != want {
+		t.Errorf("expected %+v, got %+v", want, cfg.GitLab.Instances[0])
+	}
+	if cfg.GitLab.Instances[1].URL != "https://gitlab.lab.example.com" || cfg.GitLab.Instances[1].Token != "glpat_lab" {
+		t.Errorf("unexpected second instance: %+v", cfg.GitLab.Instances[1])
+	}
+}
+
+func TestLoad_RemoteOverrides(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	content := `
+[[remotes]]
+match = "*.git.corp"
+provider = "gitlab"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Remotes) != 1 {
+		t.Fatalf("expected 1 remote override, got %d", len(cfg.Remotes))
+	}
+	if cfg.Remotes[0].Match != "*.git.corp" || cfg.Remotes[0].Provider != "gitlab" {
+		t.Errorf("unexpected remote override: %+v", cfg.Remotes[0])
+	}
+}
+
+func TestLoad_DiskCache(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("disk_cache = true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.DiskCache {
+		t.Error("expected disk cache to be enabled")
+	}
+}
+
+func TestLoad_NetworkConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	content := `
+[network]
+proxy = "http://proxy.corp:3128"
+ca_bundle = "/etc/ssl/corp-ca.pem"
+client_cert = "/etc/ssl/me.pem"
+client_key = "/etc/ssl/me-key.pem"
+timeout = "30s"
+insecure_skip_verify = true
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := config.NetworkConfig{
+		Proxy:              "http://proxy.corp:3128",
+		CABundle:           "/etc/ssl/corp-ca.pem",
+		ClientCert:         "/etc/ssl/me.pem",
+		ClientKey:          "/etc/ssl/me-key.pem",
+		Timeout:            30 * time.Second,
+		InsecureSkipVerify: true,
+	}
+	if cfg.Network != want {
+		t.Errorf("expected %+v, got %+v", want, cfg.Network)
+	}
+
+	if err := config.Save(configPath, cfg); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+	reloaded, err := config.LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error reloading: %v", err)
+	}
+	if reloaded.Network != want {
+		t.Errorf("expected network config to survive a save, got %+v", reloaded.Network)
+	}
+}
@@ -0,0 +1,55 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/cron"
+)
+
+func TestNext(t *testing.T) {
+	// 2026-01-01 is a Thursday.
+	from := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2026, 1, 2, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1 feb *", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)},
+		{"5,35 */6 * * *", time.Date(2026, 1, 1, 12, 5, 0, 0, time.UTC)},
+		// Day of month and day of week both restricted: either one matches.
+		{"0 0 15 * SUN", time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		s, err := cron.Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.expr, err)
+		}
+		if got := s.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q: expected %s, got %s", tt.expr, tt.want, got)
+		}
+	}
+}
+
+func TestNext_NeverMatchingScheduleReturnsZero(t *testing.T) {
+	s, err := cron.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("expected zero time, got %s", got)
+	}
+}
+
+func TestParse_RejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *"} {
+		if _, err := cron.Parse(expr); err == nil {
+			t.Errorf("%q: expected error", expr)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+// AnnotationLevel is the severity of an annotation.
+type AnnotationLevel string
+
+const (
+	AnnotationNotice  AnnotationLevel = "notice"
+	AnnotationWarning AnnotationLevel = "warning"
+	AnnotationFailure AnnotationLevel = "failure"
+)
+
+// Annotation is a finding attached to a file and line by a CI job,
+// such as a lint error or a failed test.
+type Annotation struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	Level     AnnotationLevel
+	Title     string
+	Message   string
+}
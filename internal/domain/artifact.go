@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// Artifact represents a file archive produced by a pipeline run.
+// On GitHub artifacts belong to the workflow run; on GitLab they belong to
+// a single job, identified by JobID.
+type Artifact struct {
+	ID        string
+	Name      string
+	JobID     string
+	Size      int64 // bytes
+	ExpiresAt time.Time
+	Expired   bool
+}
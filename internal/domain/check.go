@@ -0,0 +1,26 @@
+package domain
+
+// CheckKind tells which mechanism reported a commit check.
+type CheckKind string
+
+const (
+	// CheckRun is a GitHub check run, reported by GitHub Actions or a GitHub App.
+	CheckRun CheckKind = "check run"
+	// CheckSuite is a GitHub check suite grouping the check runs of one app.
+	CheckSuite CheckKind = "check suite"
+	// CommitStatus is a commit status reported through the statuses API,
+	// typically by external CI systems.
+	CommitStatus CheckKind = "status"
+)
+
+// CommitCheck is one result reported against a commit, from any CI system.
+// Source names the app or system that reported it, and URL links to its
+// details; both may be empty.
+type CommitCheck struct {
+	Name        string
+	Kind        CheckKind
+	Source      string
+	Status      PipelineStatus
+	Description string
+	URL         string
+}
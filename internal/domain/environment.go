@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Environment is a deployment target such as staging or production.
+// LastDeployment is nil if nothing has been deployed to it yet.
+type Environment struct {
+	Name           string
+	LastDeployment *Deployment
+}
+
+// Deployment is a single deployment of a ref to an environment.
+// PipelineID identifies the pipeline that performed the deployment, and is
+// empty when the provider does not link the two.
+type Deployment struct {
+	ID         string
+	Ref        string
+	CommitSHA  string
+	Status     PipelineStatus
+	Author     string
+	CreatedAt  time.Time
+	PipelineID string
+}
@@ -1,8 +1,89 @@
 // internal/domain/errors.go
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // ErrUnauthorized is returned by providers when the API responds with HTTP 401.
 // Callers can check for it using errors.Is to trigger token refresh or re-auth.
 var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrForbidden is returned by providers when the API responds with HTTP 403,
+// typically because the token lacks a scope or the user lacks a role.
+var ErrForbidden = errors.New("forbidden")
+
+// ErrNotFound is returned by providers when the API responds with HTTP 404.
+// Both APIs also answer 404 for resources the token is not allowed to see.
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict is returned by providers when the API responds with HTTP 409,
+// e.g. when canceling a run that has already finished.
+var ErrConflict = errors.New("conflict")
+
+// ErrNotSupported is returned when a provider does not implement an optional
+// capability, such as artifacts on a CI system that has no artifact API.
+var ErrNotSupported = errors.New("not supported by this provider")
+
+// APIError is an error response from a provider's API. Message is the
+// explanation from the response body, if the API gave one. Err is the
+// sentinel for the status, such as ErrNotFound, so callers can use errors.Is.
+type APIError struct {
+	Provider string
+	Status   string
+	Message  string
+	Err      error
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s API error: %s", e.Provider, e.Status)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// RateLimitedError is returned by providers when the API rejected a request
+// because the rate limit was exceeded. RetryAt is when requests are accepted
+// again, or the zero time if the API did not say.
+type RateLimitedError struct {
+	Provider string
+	RetryAt  time.Time
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAt.IsZero() {
+		return fmt.Sprintf("%s API rate limit exceeded", e.Provider)
+	}
+	return fmt.Sprintf("%s API rate limit exceeded: retrying after %s", e.Provider, e.RetryAt.Format("15:04:05"))
+}
+
+// TransientError wraps a failure that is expected to clear up on its own,
+// such as a 503 from the API or a dropped connection. Retrying later may
+// succeed, so callers keep showing the data they already have.
+type TransientError struct {
+	Err error
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransient reports whether err is temporary: a TransientError or a
+// RateLimitedError. Any other error is permanent until something changes,
+// such as the token or the repository.
+func IsTransient(err error) bool {
+	var transient *TransientError
+	var rateLimited *RateLimitedError
+	return errors.As(err, &transient) || errors.As(err, &rateLimited)
+}
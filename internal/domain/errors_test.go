@@ -15,3 +15,16 @@ func TestErrUnauthorized_CanBeDetectedWithErrorsIs(t *testing.T) {
 		t.Error("expected errors.Is to detect ErrUnauthorized in wrapped error")
 	}
 }
+
+func TestIsTransient_DistinguishesTemporaryFailures(t *testing.T) {
+	transient := fmt.Errorf("loading pipelines: %w", &domain.TransientError{Err: errors.New("connection reset")})
+	if !domain.IsTransient(transient) {
+		t.Error("expected a wrapped TransientError to be transient")
+	}
+	if !domain.IsTransient(&domain.RateLimitedError{Provider: "github"}) {
+		t.Error("expected a RateLimitedError to be transient")
+	}
+	if domain.IsTransient(fmt.Errorf("gitlab API error: %w", domain.ErrUnauthorized)) {
+		t.Error("expected ErrUnauthorized to be permanent")
+	}
+}
@@ -25,25 +25,121 @@ type Step struct {
 }
 
 // Job represents a single unit of work within a pipeline.
+// QueuedAt is when the job entered the queue; the gap until StartedAt is
+// time spent waiting for a runner. Queued is the time the job had spent
+// waiting as of when it was fetched, for providers that report it rather
+// than when the job entered the queue. Downstream is set for trigger jobs that
+// start another pipeline, and is nil otherwise. Attempt counts the tries of
+// the job within its pipeline starting at 1 (0 if unknown), and Retried marks
+// a try that has been superseded by a later one. Runner is the name of the
+// runner that picked the job up (empty while queued), and RunnerLabels are
+// the labels or tags a runner needs to pick it up.
 type Job struct {
-	ID        string
-	Name      string
-	Stage     string
-	Status    PipelineStatus
-	Duration  time.Duration
-	StartedAt time.Time
-	Steps     []Step
+	ID           string
+	Name         string
+	Stage        string
+	Status       PipelineStatus
+	Duration     time.Duration
+	QueuedAt     time.Time
+	Queued       time.Duration
+	StartedAt    time.Time
+	Steps        []Step
+	Downstream   *DownstreamPipeline
+	Attempt      int
+	Retried      bool
+	Runner       string
+	RunnerLabels []string
+}
+
+// QueuedDuration returns how long the job waited for a runner. For a job that
+// has not started yet it is the time queued so far, measured up to now.
+// Returns 0 if the queue time is unknown or the job ended without starting.
+func (j Job) QueuedDuration(now time.Time) time.Duration {
+	if j.QueuedAt.IsZero() {
+		return j.Queued
+	}
+	end := j.StartedAt
+	if end.IsZero() {
+		if j.Status != StatusPending && j.Status != StatusRunning {
+			return 0
+		}
+		end = now
+	}
+	if end.Before(j.QueuedAt) {
+		return 0
+	}
+	return end.Sub(j.QueuedAt)
+}
+
+// DownstreamPipeline is a pipeline triggered by a job of another pipeline,
+// such as a GitLab child or multi-project pipeline. Repository differs from
+// the upstream one for multi-project pipelines.
+type DownstreamPipeline struct {
+	Repository Repository
+	Pipeline   Pipeline
+}
+
+// MergeRequestKind tells GitHub pull requests and GitLab merge requests apart.
+type MergeRequestKind string
+
+const (
+	// KindPullRequest is a GitHub pull request, referred to as "#number".
+	KindPullRequest MergeRequestKind = "pull request"
+	// KindMergeRequest is a GitLab merge request, referred to as "!iid".
+	KindMergeRequest MergeRequestKind = "merge request"
+)
+
+// MergeRequest is the pull request (GitHub) or merge request (GitLab) a
+// pipeline was run for. Kind is empty if the provider did not say.
+type MergeRequest struct {
+	Kind         MergeRequestKind
+	Number       int
+	Title        string
+	SourceBranch string
+	TargetBranch string
+	Draft        bool
 }
 
 // Pipeline represents a CI pipeline run.
+// MergeRequest is nil when the pipeline is not associated with a review.
+// Workflow and WorkflowID identify the workflow definition the run belongs to,
+// for providers that have several per repository (GitHub Actions). Event is
+// what triggered the run, e.g. "push" or "schedule", and Attempt counts reruns
+// of the same run starting at 1 (0 if the provider does not track attempts).
+// Author is who the provider credits with the run: the triggering user on
+// GitLab, the head commit's author on GitHub. CommitAuthor is the author of
+// the commit, for providers that report it separately (GitLab only).
 type Pipeline struct {
-	ID        string
-	Branch    string
-	CommitSHA string
-	CommitMsg string
-	Author    string
-	Status    PipelineStatus
-	CreatedAt time.Time
-	Duration  time.Duration
-	Jobs      []Job
+	ID           string
+	Branch       string
+	CommitSHA    string
+	CommitMsg    string
+	Author       string
+	CommitAuthor string
+	Status       PipelineStatus
+	CreatedAt    time.Time
+	Duration     time.Duration
+	Workflow     string
+	WorkflowID   string
+	Event        string
+	Attempt      int
+	MergeRequest *MergeRequest
+	Jobs         []Job
+}
+
+// PipelineVariables are the variables a pipeline was triggered with. When the
+// provider cannot tell which of them are secret, every variable is Masked and
+// ValuesHidden says why.
+type PipelineVariables struct {
+	Variables    []Variable
+	ValuesHidden string
+}
+
+// Variable is a variable a pipeline was triggered with.
+// Providers leave Value empty for masked variables, so secret values never
+// reach the UI.
+type Variable struct {
+	Key    string
+	Value  string
+	Masked bool
 }
@@ -0,0 +1,37 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+func TestJob_QueuedDuration(t *testing.T) {
+	queued := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := queued.Add(5 * time.Minute)
+
+	started := domain.Job{Status: domain.StatusSuccess, QueuedAt: queued, StartedAt: queued.Add(30 * time.Second)}
+	if got := started.QueuedDuration(now); got != 30*time.Second {
+		t.Errorf("expected 30s for a started job, got %s", got)
+	}
+	waiting := domain.Job{Status: domain.StatusPending, QueuedAt: queued}
+	if got := waiting.QueuedDuration(now); got != 5*time.Minute {
+		t.Errorf("expected 5m for a waiting job, got %s", got)
+	}
+	if got := (domain.Job{Status: domain.StatusPending}).QueuedDuration(now); got != 0 {
+		t.Errorf("expected 0 without a queue time, got %s", got)
+	}
+	picked := domain.Job{Status: domain.StatusRunning, QueuedAt: queued}
+	if got := picked.QueuedDuration(now); got != 5*time.Minute {
+		t.Errorf("expected 5m for a running job not yet started, got %s", got)
+	}
+	cancelled := domain.Job{Status: domain.StatusCancelled, QueuedAt: queued}
+	if got := cancelled.QueuedDuration(now); got != 0 {
+		t.Errorf("expected 0 for a job that ended without starting, got %s", got)
+	}
+	reported := domain.Job{Status: domain.StatusPending, Queued: 90 * time.Second}
+	if got := reported.QueuedDuration(now); got != 90*time.Second {
+		t.Errorf("expected the reported 90s, got %s", got)
+	}
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "io"
+
 // PipelineID is the unique identifier for a pipeline run.
 // Using a distinct type prevents confusion with other string parameters.
 type PipelineID string
@@ -23,3 +25,102 @@ type PipelineProvider interface {
 	// CancelPipeline cancels a running pipeline.
 	CancelPipeline(repo Repository, id PipelineID) error
 }
+
+// ArtifactProvider is implemented by providers that can list and download
+// the build artifacts of a pipeline.
+type ArtifactProvider interface {
+	// ListArtifacts returns the artifacts produced by the given pipeline.
+	ListArtifacts(repo Repository, id PipelineID) ([]Artifact, error)
+
+	// DownloadArtifact streams the artifact archive to dst.
+	// progress, if not nil, is called as bytes are written with the running
+	// total and the expected size (0 if unknown).
+	DownloadArtifact(repo Repository, artifact Artifact, dst io.Writer, progress func(written, total int64)) error
+}
+
+// TestReportProvider is implemented by providers that can report the test
+// results of a pipeline.
+type TestReportProvider interface {
+	// GetTestReport returns the test case results of the given pipeline.
+	GetTestReport(repo Repository, id PipelineID) (TestReport, error)
+}
+
+// VariableProvider is implemented by providers that can report the variables
+// a pipeline was triggered with. GitHub does not implement it: its REST API
+// does not report the workflow_dispatch inputs of a run.
+type VariableProvider interface {
+	// GetPipelineVariables returns the variables of the given pipeline.
+	GetPipelineVariables(repo Repository, id PipelineID) (PipelineVariables, error)
+}
+
+// AnnotationProvider is implemented by providers that attach file and line
+// annotations to jobs.
+type AnnotationProvider interface {
+	// GetJobAnnotations returns the annotations reported by the given job.
+	GetJobAnnotations(repo Repository, jobID JobID) ([]Annotation, error)
+}
+
+// MergeRequestPipelineProvider is implemented by providers that can list the
+// pipelines run for a pull or merge request.
+type MergeRequestPipelineProvider interface {
+	// ListMergeRequestPipelines returns the most recent pipelines of the given review.
+	ListMergeRequestPipelines(repo Repository, mr MergeRequest) ([]Pipeline, error)
+}
+
+// WorkflowPipelineProvider is implemented by providers that can list the
+// pipelines of a single workflow definition.
+type WorkflowPipelineProvider interface {
+	// ListWorkflowPipelines returns the most recent pipelines of the given workflow.
+	ListWorkflowPipelines(repo Repository, workflowID string) ([]Pipeline, error)
+}
+
+// AttemptProvider is implemented by providers that keep the earlier attempts
+// of a pipeline that has been rerun in place.
+type AttemptProvider interface {
+	// GetPipelineAttempt returns the given attempt of a pipeline, starting at 1,
+	// with the jobs that ran in that attempt.
+	GetPipelineAttempt(repo Repository, id PipelineID, attempt int) (Pipeline, error)
+}
+
+// RateLimitReporter is implemented by providers that track the API request
+// quota reported by the API.
+type RateLimitReporter interface {
+	// RateLimit returns the quota as of the last response.
+	// Returns false until the API has reported one.
+	RateLimit() (RateLimit, bool)
+}
+
+// CommitCheckProvider is implemented by providers that can report every check
+// and status attached to a commit, including those of external CI systems.
+type CommitCheckProvider interface {
+	// ListCommitChecks returns the checks and statuses reported for the commit.
+	ListCommitChecks(repo Repository, sha string) ([]CommitCheck, error)
+}
+
+// RunnerProvider is implemented by providers that can list the runners available to a repository.
+type RunnerProvider interface {
+	// ListRunners returns the runners that can pick up the repository's jobs.
+	ListRunners(repo Repository) ([]Runner, error)
+}
+
+// EnvironmentProvider is implemented by providers that track deployments to environments.
+type EnvironmentProvider interface {
+	// ListEnvironments returns the environments of the repository with their last deployment.
+	ListEnvironments(repo Repository) ([]Environment, error)
+}
+
+// ScheduleProvider is implemented by providers that run pipelines on a schedule.
+type ScheduleProvider interface {
+	// ListSchedules returns the pipeline schedules of the repository.
+	ListSchedules(repo Repository) ([]Schedule, error)
+}
+
+// ScheduleController is implemented by providers whose schedules can be
+// triggered and paused from gitdeck.
+type ScheduleController interface {
+	// RunSchedule triggers a pipeline for the schedule immediately.
+	RunSchedule(repo Repository, scheduleID string) error
+
+	// SetScheduleActive activates or deactivates the schedule.
+	SetScheduleActive(repo Repository, scheduleID string, active bool) error
+}
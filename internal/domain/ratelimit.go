@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// RateLimit is the API request quota of a provider: how many of Limit requests
+// are left until the quota resets at ResetAt.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
@@ -0,0 +1,14 @@
+package domain
+
+// Runner is a machine that executes jobs: a GitLab runner or a GitHub
+// self-hosted runner. Scope tells where the runner is registered, such as
+// "project", "group", "instance" or "repository".
+type Runner struct {
+	ID     string
+	Name   string
+	Labels []string
+	Online bool
+	Busy   bool
+	Paused bool
+	Scope  string
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// Schedule is a recurring pipeline trigger, such as a nightly build.
+// NextRunAt is zero when the next run is unknown or the schedule is inactive.
+// LastStatus and LastPipelineID describe the most recent scheduled run, and
+// are empty if the schedule has not run yet.
+type Schedule struct {
+	ID             string
+	Description    string
+	Ref            string
+	Cron           string
+	Active         bool
+	NextRunAt      time.Time
+	LastStatus     PipelineStatus
+	LastPipelineID string
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// TestStatus represents the outcome of a single test case.
+type TestStatus string
+
+const (
+	TestPassed  TestStatus = "passed"
+	TestFailed  TestStatus = "failed"
+	TestErrored TestStatus = "error"
+	TestSkipped TestStatus = "skipped"
+)
+
+// TestCase represents a single test case result from a test report.
+// Message and StackTrace are only set for failed or errored cases.
+type TestCase struct {
+	Suite      string
+	ClassName  string
+	Name       string
+	Status     TestStatus
+	Duration   time.Duration
+	Message    string
+	StackTrace string
+}
+
+// TestReport holds the test case results of a pipeline. Warnings describe
+// sources of results that were skipped or could not be read, in which case
+// the report may be incomplete.
+type TestReport struct {
+	Cases    []TestCase
+	Warnings []string
+}
+
+// Failed returns the failed and errored test cases in report order.
+func (r TestReport) Failed() []TestCase {
+	var failed []TestCase
+	for _, c := range r.Cases {
+		if c.Status == TestFailed || c.Status == TestErrored {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Count returns the number of test cases with the given status.
+func (r TestReport) Count(status TestStatus) int {
+	n := 0
+	for _, c := range r.Cases {
+		if c.Status == status {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,66 @@
+package fakeci_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/waabox/gitdeck/internal/fakeci"
+	"github.com/waabox/gitdeck/internal/provider"
+	gitlabprovider "github.com/waabox/gitdeck/internal/provider/gitlab"
+	"github.com/waabox/gitdeck/internal/tui"
+)
+
+// TestEndToEnd_TUIFollowsRunThroughTokenRefresh drives the TUI against the
+// fake GitLab API through a RefreshingProvider, as main wires them up.
+func TestEndToEnd_TUIFollowsRunThroughTokenRefresh(t *testing.T) {
+	srv := fakeci.NewGitLab(repo.Owner, repo.Name)
+	defer srv.Close()
+	srv.AddRun(buildRun(0))
+
+	adapter := gitlabprovider.NewAdapter(srv.Token(), srv.URL, 20)
+	refreshes := 0
+	p := provider.NewRefreshingProvider(adapter, "gitlab",
+		func() (string, error) {
+			refreshes++
+			return srv.Token(), nil
+		},
+		adapter.SetToken,
+	)
+
+	var m tea.Model = tui.NewAppModel(repo, p)
+	// Init batches the first load with the auto-refresh tick; run only the load.
+	m = update(t, m, m.Init()().(tea.BatchMsg)[0]())
+	if view := m.View(); !strings.Contains(view, "#1001") || !strings.Contains(view, "↷") {
+		t.Fatalf("expected the queued pipeline, got:\n%s", view)
+	}
+
+	srv.Advance(5 * time.Minute)
+	srv.ExpireToken()
+	m = update(t, m, key(t, m, tea.KeyMsg{Type: tea.KeyCtrlR}))
+	if view := m.View(); !strings.Contains(view, "✓") || strings.Contains(view, "Error") {
+		t.Errorf("expected the finished pipeline after a silent token refresh, got:\n%s", view)
+	}
+	if refreshes != 1 {
+		t.Errorf("expected one token refresh, got %d", refreshes)
+	}
+}
+
+// key sends a key press to the model and returns the message of the command
+// it starts.
+func key(t *testing.T, m tea.Model, msg tea.KeyMsg) tea.Msg {
+	t.Helper()
+	_, cmd := m.Update(msg)
+	if cmd == nil {
+		t.Fatalf("expected %v to start a command", msg)
+	}
+	return cmd()
+}
+
+func update(t *testing.T, m tea.Model, msg tea.Msg) tea.Model {
+	t.Helper()
+	next, _ := m.Update(msg)
+	return next
+}
@@ -0,0 +1,217 @@
+package fakeci
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (s *Server) routeGitHub(mux *http.ServeMux) {
+	mux.HandleFunc("GET /repos/{owner}/{repo}/actions/runs", s.githubListRuns)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/actions/runs/{id}", s.githubGetRun)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/actions/runs/{id}/jobs", s.githubListJobs)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/actions/runs/{id}/attempts/{attempt}", s.githubGetRun)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/actions/runs/{id}/attempts/{attempt}/jobs", s.githubListJobs)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/actions/runs/{id}/rerun", s.githubRerun)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/actions/runs/{id}/cancel", s.githubCancel)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/actions/jobs/{id}/logs", s.githubJobLogs)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) { writeNotFound(w) })
+}
+
+// githubStatus maps a simulated status to GitHub's status and conclusion.
+func githubStatus(status string) (string, string) {
+	switch status {
+	case "pending":
+		return "queued", ""
+	case "running":
+		return "in_progress", ""
+	case "failed":
+		return "completed", "failure"
+	case "canceled":
+		return "completed", "cancelled"
+	default:
+		return "completed", "success"
+	}
+}
+
+func (s *Server) githubRun(r *run, a *attempt, now time.Time) map[string]interface{} {
+	status, updated := summarize(a.jobs(r, now))
+	state, conclusion := githubStatus(status)
+	if updated.IsZero() {
+		updated = a.started
+	}
+	commit := map[string]interface{}{
+		"message": r.spec.Message,
+		"author":  map[string]string{"name": r.spec.Author},
+	}
+	return map[string]interface{}{
+		"id":            r.id,
+		"name":          r.spec.Workflow,
+		"workflow_id":   1,
+		"event":         "push",
+		"run_attempt":   a.number,
+		"head_branch":   r.spec.Branch,
+		"head_sha":      r.spec.SHA,
+		"head_commit":   commit,
+		"status":        state,
+		"conclusion":    conclusion,
+		"created_at":    formatTime(r.created),
+		"updated_at":    formatTime(updated),
+		"pull_requests": []interface{}{},
+	}
+}
+
+func githubJob(j jobState) map[string]interface{} {
+	state, conclusion := githubStatus(j.status)
+	step := map[string]interface{}{
+		"name":         "Run " + j.spec.Name,
+		"status":       state,
+		"conclusion":   conclusion,
+		"started_at":   formatTime(j.started),
+		"completed_at": formatTime(j.finished),
+	}
+	job := map[string]interface{}{
+		"id":           j.id,
+		"name":         j.spec.Name,
+		"status":       state,
+		"conclusion":   conclusion,
+		"created_at":   formatTime(j.queued),
+		"started_at":   formatTime(j.started),
+		"completed_at": formatTime(j.finished),
+		"run_attempt":  j.attempt,
+		"labels":       []string{"ubuntu-latest"},
+		"steps":        []interface{}{step},
+	}
+	if !j.started.IsZero() {
+		job["runner_name"] = "fake-runner"
+	}
+	return job
+}
+
+// githubAttempt returns the run and attempt addressed by the request: the
+// attempt in the path, or the latest one. Callers must hold s.mu.
+func (s *Server) githubAttempt(r *http.Request) (*run, *attempt, bool) {
+	if !s.repoMatches(r.PathValue("owner"), r.PathValue("repo")) {
+		return nil, nil, false
+	}
+	found := s.findRun(r.PathValue("id"))
+	if found == nil {
+		return nil, nil, false
+	}
+	number := len(found.attempts)
+	if v := r.PathValue("attempt"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > len(found.attempts) {
+			return nil, nil, false
+		}
+		number = n
+	}
+	return found, found.attempts[number-1], true
+}
+
+func (s *Server) githubListRuns(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.repoMatches(r.PathValue("owner"), r.PathValue("repo")) {
+		writeNotFound(w)
+		return
+	}
+	now := s.now()
+	branch := r.URL.Query().Get("branch")
+	runs := []interface{}{}
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		run := s.runs[i]
+		if branch != "" && run.spec.Branch != branch {
+			continue
+		}
+		runs = append(runs, s.githubRun(run, run.attempts[len(run.attempts)-1], now))
+	}
+	from, to := page(w, r, len(runs), 30, false)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total_count":   len(runs),
+		"workflow_runs": runs[from:to],
+	})
+}
+
+func (s *Server) githubGetRun(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, a, ok := s.githubAttempt(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.githubRun(run, a, s.now()))
+}
+
+func (s *Server) githubListJobs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, a, ok := s.githubAttempt(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	states := a.jobs(run, s.now())
+	jobs := make([]interface{}, len(states))
+	for i, j := range states {
+		jobs[i] = githubJob(j)
+	}
+	from, to := page(w, r, len(jobs), 30, false)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total_count": len(jobs),
+		"jobs":        jobs[from:to],
+	})
+}
+
+func (s *Server) githubRerun(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, a, ok := s.githubAttempt(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	now := s.now()
+	if status, _ := summarize(a.jobs(run, now)); !finished(status) {
+		writeError(w, http.StatusForbidden, "This workflow is already running")
+		return
+	}
+	s.startAttempt(run, now)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{})
+}
+
+func (s *Server) githubCancel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, a, ok := s.githubAttempt(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	now := s.now()
+	if status, _ := summarize(a.jobs(run, now)); finished(status) {
+		writeError(w, http.StatusConflict, "Cannot cancel a workflow run that is completed.")
+		return
+	}
+	a.canceledAt = now
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{})
+}
+
+// githubJobLogs serves a job's log once the job has started; GitHub answers
+// 404 until then.
+func (s *Server) githubJobLogs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.repoMatches(r.PathValue("owner"), r.PathValue("repo")) {
+		writeNotFound(w)
+		return
+	}
+	j, ok := s.findJob(r.PathValue("id"), s.now())
+	if !ok || j.started.IsZero() {
+		writeNotFound(w)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(j.spec.Log))
+}
@@ -0,0 +1,204 @@
+package fakeci
+
+import (
+	"net/http"
+	"time"
+)
+
+func (s *Server) routeGitLab(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v4/projects/{project}/pipelines", s.gitlabListPipelines)
+	mux.HandleFunc("GET /api/v4/projects/{project}/pipelines/{id}", s.gitlabGetPipeline)
+	mux.HandleFunc("GET /api/v4/projects/{project}/pipelines/{id}/jobs", s.gitlabListJobs)
+	mux.HandleFunc("GET /api/v4/projects/{project}/pipelines/{id}/bridges", s.gitlabEmptyList)
+	mux.HandleFunc("GET /api/v4/projects/{project}/pipelines/{id}/variables", s.gitlabEmptyList)
+	mux.HandleFunc("GET /api/v4/projects/{project}/variables", s.gitlabEmptyList)
+	mux.HandleFunc("GET /api/v4/projects/{project}/repository/commits/{sha}", s.gitlabGetCommit)
+	mux.HandleFunc("GET /api/v4/projects/{project}/jobs/{id}/trace", s.gitlabJobTrace)
+	mux.HandleFunc("POST /api/v4/projects/{project}/pipelines/{id}/retry", s.gitlabRetry)
+	mux.HandleFunc("POST /api/v4/projects/{project}/pipelines/{id}/cancel", s.gitlabCancel)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) { writeError(w, http.StatusNotFound, "404 Not Found") })
+}
+
+func (s *Server) gitlabProjectMatches(r *http.Request) bool {
+	return r.PathValue("project") == s.owner+"/"+s.name
+}
+
+// gitlabRun returns the run addressed by the request. Callers must hold s.mu.
+func (s *Server) gitlabRun(r *http.Request) (*run, bool) {
+	if !s.gitlabProjectMatches(r) {
+		return nil, false
+	}
+	found := s.findRun(r.PathValue("id"))
+	return found, found != nil
+}
+
+func (s *Server) gitlabPipeline(r *run, now time.Time) map[string]interface{} {
+	latest := r.attempts[len(r.attempts)-1]
+	status, updated := summarize(latest.jobs(r, now))
+	if updated.IsZero() {
+		updated = latest.started
+	}
+	return map[string]interface{}{
+		"id":         r.id,
+		"ref":        r.spec.Branch,
+		"sha":        r.spec.SHA,
+		"status":     status,
+		"source":     "push",
+		"created_at": formatTime(r.created),
+		"updated_at": formatTime(updated),
+	}
+}
+
+func gitlabJob(j jobState, retried bool) map[string]interface{} {
+	job := map[string]interface{}{
+		"id":          j.id,
+		"name":        j.spec.Name,
+		"stage":       j.spec.Stage,
+		"status":      j.status,
+		"created_at":  formatTime(j.queued),
+		"started_at":  formatTime(j.started),
+		"finished_at": formatTime(j.finished),
+		"retried":     retried,
+		"tag_list":    []string{"docker"},
+	}
+	if !j.started.IsZero() {
+		job["queued_duration"] = j.started.Sub(j.queued).Seconds()
+		job["runner"] = map[string]interface{}{"id": 1, "description": "fake-runner", "runner_type": "project_type"}
+	}
+	return job
+}
+
+func (s *Server) gitlabListPipelines(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.gitlabProjectMatches(r) {
+		writeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	now := s.now()
+	pipelines := make([]interface{}, 0, len(s.runs))
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		pipelines = append(pipelines, s.gitlabPipeline(s.runs[i], now))
+	}
+	from, to := page(w, r, len(pipelines), 20, true)
+	writeJSON(w, http.StatusOK, pipelines[from:to])
+}
+
+func (s *Server) gitlabGetPipeline(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.gitlabRun(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	pipeline := s.gitlabPipeline(run, s.now())
+	pipeline["user"] = map[string]string{"name": run.spec.Author, "username": run.spec.Author}
+	writeJSON(w, http.StatusOK, pipeline)
+}
+
+// gitlabListJobs lists the jobs of the latest attempt, preceded by those of
+// earlier attempts marked as retried if include_retried is set.
+func (s *Server) gitlabListJobs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.gitlabRun(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	now := s.now()
+	attempts := run.attempts[len(run.attempts)-1:]
+	if r.URL.Query().Get("include_retried") == "true" {
+		attempts = run.attempts
+	}
+	jobs := []interface{}{}
+	for _, a := range attempts {
+		retried := a.number < len(run.attempts)
+		for _, j := range a.jobs(run, now) {
+			jobs = append(jobs, gitlabJob(j, retried))
+		}
+	}
+	from, to := page(w, r, len(jobs), 20, true)
+	writeJSON(w, http.StatusOK, jobs[from:to])
+}
+
+func (s *Server) gitlabEmptyList(w http.ResponseWriter, r *http.Request) {
+	page(w, r, 0, 20, true)
+	writeJSON(w, http.StatusOK, []interface{}{})
+}
+
+func (s *Server) gitlabGetCommit(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.gitlabProjectMatches(r) {
+		for _, run := range s.runs {
+			if run.spec.SHA == r.PathValue("sha") {
+				writeJSON(w, http.StatusOK, map[string]string{
+					"id":          run.spec.SHA,
+					"title":       firstLine(run.spec.Message),
+					"message":     run.spec.Message,
+					"author_name": run.spec.Author,
+				})
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "404 Commit Not Found")
+}
+
+// gitlabJobTrace serves a job's log; GitLab answers with an empty trace
+// until the job has started.
+func (s *Server) gitlabJobTrace(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.gitlabProjectMatches(r) {
+		writeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	j, ok := s.findJob(r.PathValue("id"), s.now())
+	if !ok {
+		writeError(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	if !j.started.IsZero() {
+		w.Write([]byte(j.spec.Log))
+	}
+}
+
+// gitlabRetry starts a new attempt of a finished pipeline, retrying every
+// job. Like GitLab, retrying a pipeline that is still running does nothing.
+func (s *Server) gitlabRetry(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.gitlabRun(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	now := s.now()
+	latest := run.attempts[len(run.attempts)-1]
+	if status, _ := summarize(latest.jobs(run, now)); finished(status) {
+		s.startAttempt(run, now)
+	}
+	writeJSON(w, http.StatusCreated, s.gitlabPipeline(run, now))
+}
+
+// gitlabCancel cancels a pipeline that is still pending or running. Like
+// GitLab, canceling a finished pipeline does nothing.
+func (s *Server) gitlabCancel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.gitlabRun(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	now := s.now()
+	latest := run.attempts[len(run.attempts)-1]
+	if status, _ := summarize(latest.jobs(run, now)); !finished(status) {
+		latest.canceledAt = now
+	}
+	writeJSON(w, http.StatusOK, s.gitlabPipeline(run, now))
+}
@@ -0,0 +1,390 @@
+// Package fakeci is an in-process fake of the GitHub Actions and GitLab CI
+// REST APIs for end-to-end tests of the adapters, RefreshingProvider and TUI.
+//
+// Runs progress on the server's clock: each job waits in the queue, then
+// runs for its duration, one after the other. The clock follows real time
+// and can be moved forward with Advance, so tests control progress without
+// sleeping. Reruns create new attempts, cancel stops the current attempt,
+// list endpoints are paginated like the real APIs, and requests with any
+// token other than the current one are rejected with 401.
+package fakeci
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JobSpec describes a job of a simulated run.
+type JobSpec struct {
+	Name  string
+	Stage string
+	// Duration is how long the job runs once it has started.
+	Duration time.Duration
+	// FailAttempts is how many attempts of the job fail before it succeeds,
+	// e.g. 1 for a flaky job that passes when rerun.
+	FailAttempts int
+	// Log is the job's log.
+	Log string
+}
+
+// RunSpec describes a simulated pipeline run.
+type RunSpec struct {
+	Branch   string
+	SHA      string
+	Message  string
+	Author   string
+	Workflow string
+	// QueueFor is how long each attempt stays pending before its first job starts.
+	QueueFor time.Duration
+	Jobs     []JobSpec
+}
+
+// Server is a fake CI API for a single repository.
+type Server struct {
+	// URL is the base URL to pass to the adapter.
+	URL string
+
+	flavor flavor
+	owner  string
+	name   string
+	srv    *httptest.Server
+
+	mu       sync.Mutex
+	offset   time.Duration
+	token    string
+	nextID   int64
+	runs     []*run
+	requests int
+}
+
+type flavor int
+
+const (
+	flavorGitHub flavor = iota
+	flavorGitLab
+)
+
+// run is a simulated pipeline with its attempts, the latest last.
+type run struct {
+	id       int64
+	spec     RunSpec
+	created  time.Time
+	attempts []*attempt
+}
+
+// attempt is one execution of every job of a run.
+type attempt struct {
+	number     int
+	started    time.Time
+	canceledAt time.Time
+	jobIDs     []int64
+}
+
+// jobState is the state of a job of an attempt at a point in time.
+type jobState struct {
+	id       int64
+	spec     JobSpec
+	attempt  int
+	queued   time.Time
+	started  time.Time
+	finished time.Time
+	status   string // pending, running, success, failed or canceled
+}
+
+// NewGitHub starts a fake GitHub API serving the repository owner/name.
+func NewGitHub(owner, name string) *Server {
+	return newServer(flavorGitHub, owner, name)
+}
+
+// NewGitLab starts a fake GitLab API serving the project owner/name.
+func NewGitLab(owner, name string) *Server {
+	return newServer(flavorGitLab, owner, name)
+}
+
+func newServer(f flavor, owner, name string) *Server {
+	s := &Server{flavor: f, owner: owner, name: name, nextID: 1000}
+	s.token = newToken()
+	mux := http.NewServeMux()
+	if f == flavorGitHub {
+		s.routeGitHub(mux)
+	} else {
+		s.routeGitLab(mux)
+	}
+	s.srv = httptest.NewServer(s.authenticate(mux))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Token returns the token the server currently accepts.
+func (s *Server) Token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+// ExpireToken makes the server reject the current token with 401 and
+// returns the new token it accepts, as a token refresh would.
+func (s *Server) ExpireToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = newToken()
+	return s.token
+}
+
+// Advance moves the server's clock forward by d.
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset += d
+}
+
+// Requests returns the number of API requests served so far.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// AddRun starts a run of spec now and returns its ID.
+func (s *Server) AddRun(spec RunSpec) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	r := &run{id: s.newID(), spec: spec, created: now}
+	s.startAttempt(r, now)
+	s.runs = append(s.runs, r)
+	return strconv.FormatInt(r.id, 10)
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// now returns the server's clock, truncated to the API's precision.
+// Callers must hold s.mu.
+func (s *Server) now() time.Time {
+	return time.Now().Add(s.offset).UTC().Truncate(time.Second)
+}
+
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) startAttempt(r *run, now time.Time) {
+	a := &attempt{number: len(r.attempts) + 1, started: now}
+	for range r.spec.Jobs {
+		a.jobIDs = append(a.jobIDs, s.newID())
+	}
+	r.attempts = append(r.attempts, a)
+}
+
+// authenticate rejects requests that do not carry the current token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		valid := r.Header.Get("Authorization") == "Bearer "+s.token
+		s.mu.Unlock()
+		if !valid {
+			message := "Bad credentials"
+			if s.flavor == flavorGitLab {
+				message = "401 Unauthorized"
+			}
+			writeError(w, http.StatusUnauthorized, message)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// findRun returns the run with the given ID. Callers must hold s.mu.
+func (s *Server) findRun(id string) *run {
+	for _, r := range s.runs {
+		if strconv.FormatInt(r.id, 10) == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// findJob returns the state of the job with the given ID. Callers must hold s.mu.
+func (s *Server) findJob(id string, now time.Time) (jobState, bool) {
+	for _, r := range s.runs {
+		for _, a := range r.attempts {
+			for _, j := range a.jobs(r, now) {
+				if strconv.FormatInt(j.id, 10) == id {
+					return j, true
+				}
+			}
+		}
+	}
+	return jobState{}, false
+}
+
+// repoMatches reports whether owner/name is the repository the server serves.
+func (s *Server) repoMatches(owner, name string) bool {
+	return owner == s.owner && name == s.name
+}
+
+// jobs returns the state of every job of the attempt at now. Jobs run one
+// after the other once the attempt has been queued for QueueFor.
+func (a *attempt) jobs(r *run, now time.Time) []jobState {
+	states := make([]jobState, len(r.spec.Jobs))
+	start := a.started.Add(r.spec.QueueFor)
+	for i, spec := range r.spec.Jobs {
+		j := jobState{id: a.jobIDs[i], spec: spec, attempt: a.number, queued: a.started}
+		end := start.Add(spec.Duration)
+		canceled := !a.canceledAt.IsZero() && a.canceledAt.Before(end)
+		switch {
+		case canceled && !a.canceledAt.After(start):
+			j.status = "canceled"
+			j.finished = a.canceledAt
+		case canceled:
+			j.status = "canceled"
+			j.started, j.finished = start, a.canceledAt
+		case now.Before(start):
+			j.status = "pending"
+		case now.Before(end):
+			j.status = "running"
+			j.started = start
+		case a.number <= spec.FailAttempts:
+			j.status = "failed"
+			j.started, j.finished = start, end
+		default:
+			j.status = "success"
+			j.started, j.finished = start, end
+		}
+		states[i] = j
+		start = end
+	}
+	return states
+}
+
+// summarize returns the status of an attempt from its jobs, and when it last
+// changed: running while any job is, pending until the first one starts, and
+// otherwise the worst result.
+func summarize(jobs []jobState) (status string, updated time.Time) {
+	status = "success"
+	started := false
+	for _, j := range jobs {
+		if j.finished.After(updated) {
+			updated = j.finished
+		}
+		switch j.status {
+		case "running":
+			return "running", updated
+		case "pending":
+			if started {
+				return "running", updated
+			}
+			status = "pending"
+		case "failed":
+			started = true
+			if status != "canceled" {
+				status = "failed"
+			}
+		case "canceled":
+			started = true
+			status = "canceled"
+		default:
+			started = true
+		}
+	}
+	if status == "pending" && started {
+		return "running", updated
+	}
+	return status, updated
+}
+
+// finished reports whether every job of the attempt is done.
+func finished(status string) bool {
+	return status == "success" || status == "failed" || status == "canceled"
+}
+
+// page returns the slice of n items requested by the per_page and page query
+// parameters, and sets the pagination headers for the response.
+func page(w http.ResponseWriter, r *http.Request, n, defaultPerPage int, gitlab bool) (int, int) {
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+	current, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || current <= 0 {
+		current = 1
+	}
+	from := (current - 1) * perPage
+	if from > n {
+		from = n
+	}
+	to := from + perPage
+	if to > n {
+		to = n
+	}
+	hasNext := to < n
+	if hasNext {
+		next := *r.URL
+		query := next.Query()
+		query.Set("page", strconv.Itoa(current+1))
+		query.Set("per_page", strconv.Itoa(perPage))
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.String()))
+	}
+	if gitlab {
+		w.Header().Set("X-Page", strconv.Itoa(current))
+		w.Header().Set("X-Per-Page", strconv.Itoa(perPage))
+		w.Header().Set("X-Total", strconv.Itoa(n))
+		if hasNext {
+			w.Header().Set("X-Next-Page", strconv.Itoa(current+1))
+		} else {
+			w.Header().Set("X-Next-Page", "")
+		}
+	}
+	return from, to
+}
+
+// formatTime renders t as the APIs do, or "" for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// firstLine returns the first line of a commit message.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,242 @@
+package fakeci_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/fakeci"
+	githubprovider "github.com/waabox/gitdeck/internal/provider/github"
+	gitlabprovider "github.com/waabox/gitdeck/internal/provider/gitlab"
+)
+
+var repo = domain.Repository{Owner: "waabox", Name: "gitdeck"}
+
+// adapter is the part of both adapters the tests exercise.
+type adapter interface {
+	domain.PipelineProvider
+	SetToken(token string)
+}
+
+// flavors runs a test against the fake GitHub and GitLab APIs with the
+// matching adapter. queued is the status the adapter reports for a queued
+// run: the GitHub adapter shows queued runs as running. Queued jobs are
+// pending with either adapter.
+func flavors(t *testing.T, test func(t *testing.T, srv *fakeci.Server, a adapter, queued domain.PipelineStatus)) {
+	t.Run("github", func(t *testing.T) {
+		srv := fakeci.NewGitHub(repo.Owner, repo.Name)
+		defer srv.Close()
+		test(t, srv, githubprovider.NewAdapter(srv.Token(), srv.URL, 20), domain.StatusRunning)
+	})
+	t.Run("gitlab", func(t *testing.T) {
+		srv := fakeci.NewGitLab(repo.Owner, repo.Name)
+		defer srv.Close()
+		test(t, srv, gitlabprovider.NewAdapter(srv.Token(), srv.URL, 20), domain.StatusPending)
+	})
+}
+
+func buildRun(failAttempts int) fakeci.RunSpec {
+	return fakeci.RunSpec{
+		Branch:   "main",
+		SHA:      "abc123",
+		Message:  "Add fake CI server\n\nFor end-to-end tests.",
+		Author:   "waabox",
+		Workflow: "CI",
+		QueueFor: 10 * time.Second,
+		Jobs: []fakeci.JobSpec{
+			{Name: "build", Stage: "build", Duration: time.Minute, Log: "building\n"},
+			{Name: "test", Stage: "test", Duration: time.Minute, FailAttempts: failAttempts, Log: "testing\n"},
+		},
+	}
+}
+
+func jobStatuses(p domain.Pipeline) string {
+	statuses := make([]string, len(p.Jobs))
+	for i, j := range p.Jobs {
+		statuses[i] = j.Name + "=" + string(j.Status)
+	}
+	return strings.Join(statuses, " ")
+}
+
+func TestServer_PipelineProgressesOverTime(t *testing.T) {
+	flavors(t, func(t *testing.T, srv *fakeci.Server, a adapter, queued domain.PipelineStatus) {
+		id := domain.PipelineID(srv.AddRun(buildRun(0)))
+
+		steps := []struct {
+			advance time.Duration
+			status  domain.PipelineStatus
+			jobs    string
+		}{
+			{0, queued, "build=pending test=pending"},
+			{30 * time.Second, domain.StatusRunning, "build=running test=pending"},
+			{time.Minute, domain.StatusRunning, "build=success test=running"},
+			{time.Minute, domain.StatusSuccess, "build=success test=success"},
+		}
+		for _, step := range steps {
+			srv.Advance(step.advance)
+			p, err := a.GetPipeline(repo, id)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Status != step.status || jobStatuses(p) != step.jobs {
+				t.Errorf("after %v: expected %s (%s), got %s (%s)", step.advance, step.status, step.jobs, p.Status, jobStatuses(p))
+			}
+		}
+
+		logs, err := a.GetJobLogs(repo, domain.JobID(fmt.Sprint(mustPipeline(t, a, id).Jobs[1].ID)))
+		if err != nil || logs != "testing\n" {
+			t.Errorf("expected the job log, got %q, %v", logs, err)
+		}
+	})
+}
+
+func mustPipeline(t *testing.T, a adapter, id domain.PipelineID) domain.Pipeline {
+	t.Helper()
+	p, err := a.GetPipeline(repo, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestServer_RerunCreatesNewAttempt(t *testing.T) {
+	flavors(t, func(t *testing.T, srv *fakeci.Server, a adapter, queued domain.PipelineStatus) {
+		id := domain.PipelineID(srv.AddRun(buildRun(1)))
+		srv.Advance(5 * time.Minute)
+		if p := mustPipeline(t, a, id); p.Status != domain.StatusFailed {
+			t.Fatalf("expected the flaky job to fail the first attempt, got %s (%s)", p.Status, jobStatuses(p))
+		}
+
+		if err := a.RerunPipeline(repo, id); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if p := mustPipeline(t, a, id); p.Status != queued {
+			t.Errorf("expected a queued second attempt, got %s", p.Status)
+		}
+		srv.Advance(5 * time.Minute)
+		if p := mustPipeline(t, a, id); p.Status != domain.StatusSuccess {
+			t.Errorf("expected the second attempt to pass, got %s (%s)", p.Status, jobStatuses(p))
+		}
+
+		attempts, ok := a.(domain.AttemptProvider)
+		if !ok {
+			return
+		}
+		first, err := attempts.GetPipelineAttempt(repo, id, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if first.Status != domain.StatusFailed || first.Attempt != 1 {
+			t.Errorf("expected the first attempt to stay failed, got %s attempt %d", first.Status, first.Attempt)
+		}
+	})
+}
+
+func TestServer_CancelStopsRun(t *testing.T) {
+	flavors(t, func(t *testing.T, srv *fakeci.Server, a adapter, queued domain.PipelineStatus) {
+		id := domain.PipelineID(srv.AddRun(buildRun(0)))
+		srv.Advance(30 * time.Second)
+
+		if err := a.CancelPipeline(repo, id); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		srv.Advance(5 * time.Minute)
+		p := mustPipeline(t, a, id)
+		if p.Status != domain.StatusCancelled || jobStatuses(p) != "build=cancelled test=cancelled" {
+			t.Errorf("expected the run to be cancelled, got %s (%s)", p.Status, jobStatuses(p))
+		}
+	})
+}
+
+func TestServer_GitHubRejectsCancelOfFinishedRun(t *testing.T) {
+	srv := fakeci.NewGitHub(repo.Owner, repo.Name)
+	defer srv.Close()
+	a := githubprovider.NewAdapter(srv.Token(), srv.URL, 20)
+	id := domain.PipelineID(srv.AddRun(buildRun(0)))
+	srv.Advance(5 * time.Minute)
+
+	if err := a.CancelPipeline(repo, id); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+}
+
+func TestServer_ListsNewestFirstWithPagination(t *testing.T) {
+	flavors(t, func(t *testing.T, srv *fakeci.Server, a adapter, queued domain.PipelineStatus) {
+		for i := 0; i < 3; i++ {
+			srv.AddRun(buildRun(0))
+		}
+		pipelines, err := a.ListPipelines(repo)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pipelines) != 3 || pipelines[0].ID != "1007" || pipelines[2].ID != "1001" {
+			t.Errorf("expected three runs newest first, got %+v", pipelines)
+		}
+	})
+
+	srv := fakeci.NewGitLab(repo.Owner, repo.Name)
+	defer srv.Close()
+	for i := 0; i < 3; i++ {
+		srv.AddRun(buildRun(0))
+	}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v4/projects/waabox%2Fgitdeck/pipelines?per_page=2", nil)
+	req.Header.Set("Authorization", "Bearer "+srv.Token())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.Header.Get("X-Total") != "3" || resp.Header.Get("X-Next-Page") != "2" ||
+		!strings.Contains(resp.Header.Get("Link"), `page=2`) {
+		t.Errorf("expected pagination headers, got %v", resp.Header)
+	}
+}
+
+func TestServer_GitLabListsEveryJobOfLongPipelines(t *testing.T) {
+	srv := fakeci.NewGitLab(repo.Owner, repo.Name)
+	defer srv.Close()
+	a := gitlabprovider.NewAdapter(srv.Token(), srv.URL, 20)
+	spec := buildRun(1)
+	for i := 0; i < 13; i++ {
+		name := fmt.Sprintf("lint-%d", i)
+		spec.Jobs = append(spec.Jobs, fakeci.JobSpec{Name: name, Stage: "test", Duration: time.Second, Log: name + "\n"})
+	}
+	id := domain.PipelineID(srv.AddRun(spec))
+	srv.Advance(10 * time.Minute)
+	if err := a.RerunPipeline(repo, id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	srv.Advance(10 * time.Minute)
+
+	// Both attempts hold 30 jobs, more than GitLab's default page of 20.
+	p := mustPipeline(t, a, id)
+	if len(p.Jobs) != 30 {
+		t.Fatalf("expected the jobs of both attempts, got %d (%s)", len(p.Jobs), jobStatuses(p))
+	}
+	attempts := make(map[int]int)
+	for _, j := range p.Jobs {
+		attempts[j.Attempt]++
+	}
+	if attempts[1] != 15 || attempts[2] != 15 {
+		t.Errorf("expected 15 jobs per attempt, got %v", attempts)
+	}
+}
+
+func TestServer_ExpiredTokenIsUnauthorized(t *testing.T) {
+	flavors(t, func(t *testing.T, srv *fakeci.Server, a adapter, queued domain.PipelineStatus) {
+		srv.AddRun(buildRun(0))
+		newToken := srv.ExpireToken()
+
+		if _, err := a.ListPipelines(repo); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+		a.SetToken(newToken)
+		if _, err := a.ListPipelines(repo); err != nil {
+			t.Errorf("expected the new token to be accepted, got %v", err)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -42,45 +43,67 @@ func DetectRepository(dir string) (domain.Repository, error) {
 	return domain.Repository{}, errors.New("no origin remote found in .git/config")
 }
 
-// ParseRemoteURL parses a git remote URL and returns a Repository.
-// Supports HTTPS (https://github.com/owner/repo.git) and SSH (git@github.com:owner/repo.git).
-// The RemoteURL field in the returned Repository preserves the original input URL unchanged.
-func ParseRemoteURL(rawURL string) (domain.Repository, error) {
-	originalURL := rawURL
-	normalized := strings.TrimSuffix(rawURL, ".git")
+// Remote is a git remote URL split into its parts.
+type Remote struct {
+	// Scheme is "https", "http", "ssh" or "git"; scp-like remotes such as
+	// git@github.com:owner/repo.git are reported as "ssh".
+	Scheme string
+	// Host is the lower-cased host name, without user or port.
+	Host string
+	// Port is empty unless the URL sets one.
+	Port string
+	// Path is the repository path without surrounding slashes or the .git suffix,
+	// e.g. "owner/repo".
+	Path string
+}
 
-	// SSH format: git@github.com:owner/repo
-	if strings.HasPrefix(normalized, "git@") {
-		trimmed := strings.TrimPrefix(normalized, "git@")
-		parts := strings.SplitN(trimmed, ":", 2)
-		if len(parts) != 2 {
-			return domain.Repository{}, fmt.Errorf("invalid SSH remote URL: %s", rawURL)
+// ParseRemote parses a git remote URL. It accepts URLs with a scheme
+// (https://host/owner/repo.git, ssh://git@host:2222/owner/repo.git) and the
+// scp-like syntax (git@host:owner/repo.git).
+func ParseRemote(rawURL string) (Remote, error) {
+	var remote Remote
+	if strings.Contains(rawURL, "://") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return Remote{}, fmt.Errorf("invalid remote URL: %s", rawURL)
 		}
-		ownerRepo := strings.SplitN(parts[1], "/", 2)
-		if len(ownerRepo) != 2 {
-			return domain.Repository{}, fmt.Errorf("invalid SSH remote URL path: %s", parts[1])
+		remote = Remote{Scheme: strings.ToLower(u.Scheme), Host: u.Hostname(), Port: u.Port(), Path: u.Path}
+	} else {
+		// scp-like syntax: [user@]host:path, with no slash before the colon.
+		hostPart, path, ok := strings.Cut(rawURL, ":")
+		if !ok || strings.Contains(hostPart, "/") {
+			return Remote{}, fmt.Errorf("unsupported remote URL format: %s", rawURL)
 		}
-		return domain.Repository{
-			Owner:     ownerRepo[0],
-			Name:      ownerRepo[1],
-			RemoteURL: originalURL,
-		}, nil
-	}
-
-	// HTTPS format: https://github.com/owner/repo
-	if strings.HasPrefix(normalized, "https://") || strings.HasPrefix(normalized, "http://") {
-		withoutScheme := strings.TrimPrefix(normalized, "https://")
-		withoutScheme = strings.TrimPrefix(withoutScheme, "http://")
-		parts := strings.SplitN(withoutScheme, "/", 3)
-		if len(parts) != 3 {
-			return domain.Repository{}, fmt.Errorf("invalid HTTPS remote URL: %s", rawURL)
+		if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+			hostPart = hostPart[i+1:]
 		}
-		return domain.Repository{
-			Owner:     parts[1],
-			Name:      parts[2],
-			RemoteURL: originalURL,
-		}, nil
+		remote = Remote{Scheme: "ssh", Host: hostPart, Path: path}
+	}
+	remote.Host = strings.ToLower(remote.Host)
+	remote.Path = strings.TrimSuffix(strings.Trim(remote.Path, "/"), ".git")
+	if remote.Host == "" {
+		return Remote{}, fmt.Errorf("remote URL has no host: %s", rawURL)
 	}
+	return remote, nil
+}
 
-	return domain.Repository{}, fmt.Errorf("unsupported remote URL format: %s", rawURL)
+// ParseRemoteURL parses a git remote URL and returns a Repository.
+// Supports HTTPS (https://github.com/owner/repo.git) and SSH (git@github.com:owner/repo.git,
+// ssh://git@github.com/owner/repo.git). Everything after the owner is the name,
+// so GitLab subgroups end up in Name.
+// The RemoteURL field in the returned Repository preserves the original input URL unchanged.
+func ParseRemoteURL(rawURL string) (domain.Repository, error) {
+	remote, err := ParseRemote(rawURL)
+	if err != nil {
+		return domain.Repository{}, err
+	}
+	owner, name, ok := strings.Cut(remote.Path, "/")
+	if !ok || owner == "" || name == "" {
+		return domain.Repository{}, fmt.Errorf("invalid remote URL path: %s", rawURL)
+	}
+	return domain.Repository{
+		Owner:     owner,
+		Name:      name,
+		RemoteURL: rawURL,
+	}, nil
 }
@@ -0,0 +1,112 @@
+// Package junit parses JUnit XML test reports into domain test reports.
+package junit
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// ErrNotJUnit is returned when the XML document is not a JUnit report.
+var ErrNotJUnit = errors.New("not a JUnit report")
+
+type xmlSuite struct {
+	Name   string     `xml:"name,attr"`
+	Cases  []xmlCase  `xml:"testcase"`
+	Suites []xmlSuite `xml:"testsuite"`
+}
+
+type xmlCase struct {
+	Name      string      `xml:"name,attr"`
+	ClassName string      `xml:"classname,attr"`
+	Time      string      `xml:"time,attr"`
+	Failure   *xmlFailure `xml:"failure"`
+	Error     *xmlFailure `xml:"error"`
+	Skipped   *struct{}   `xml:"skipped"`
+}
+
+type xmlFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// Parse reads a JUnit XML document. Both a <testsuites> root and a single
+// <testsuite> root are accepted; nested suites are flattened.
+// Returns ErrNotJUnit if the root element is neither.
+func Parse(r io.Reader) (domain.TestReport, error) {
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return domain.TestReport{}, ErrNotJUnit
+		}
+		if err != nil {
+			return domain.TestReport{}, fmt.Errorf("parsing JUnit XML: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		var root xmlSuite
+		switch start.Name.Local {
+		case "testsuites", "testsuite":
+			if err := dec.DecodeElement(&root, &start); err != nil {
+				return domain.TestReport{}, fmt.Errorf("parsing JUnit XML: %w", err)
+			}
+		default:
+			return domain.TestReport{}, ErrNotJUnit
+		}
+		var report domain.TestReport
+		collect(&report, root)
+		return report, nil
+	}
+}
+
+func collect(report *domain.TestReport, suite xmlSuite) {
+	for _, c := range suite.Cases {
+		report.Cases = append(report.Cases, c.toTestCase(suite.Name))
+	}
+	for _, child := range suite.Suites {
+		collect(report, child)
+	}
+}
+
+func (c xmlCase) toTestCase(suite string) domain.TestCase {
+	seconds, _ := strconv.ParseFloat(c.Time, 64)
+	tc := domain.TestCase{
+		Suite:     suite,
+		ClassName: c.ClassName,
+		Name:      c.Name,
+		Status:    domain.TestPassed,
+		Duration:  time.Duration(seconds * float64(time.Second)),
+	}
+	switch {
+	case c.Failure != nil:
+		tc.Status = domain.TestFailed
+		tc.Message, tc.StackTrace = c.Failure.details()
+	case c.Error != nil:
+		tc.Status = domain.TestErrored
+		tc.Message, tc.StackTrace = c.Error.details()
+	case c.Skipped != nil:
+		tc.Status = domain.TestSkipped
+	}
+	return tc
+}
+
+// details returns the failure message and stack trace. Reporters that only
+// fill in the element body get the body's first line as the message.
+func (f xmlFailure) details() (string, string) {
+	body := strings.TrimSpace(f.Body)
+	message := f.Message
+	if message == "" {
+		message, _, _ = strings.Cut(body, "\n")
+	}
+	return message, body
+}
@@ -0,0 +1,88 @@
+package junit_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/junit"
+)
+
+func TestParse_TestSuitesRoot(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="auth" tests="3">
+    <testcase name="TestLogin" classname="auth" time="0.25"/>
+    <testcase name="TestLogout" classname="auth" time="1.5">
+      <failure message="expected 200, got 500" type="AssertionError">auth_test.go:42: expected 200, got 500
+goroutine 7 [running]:</failure>
+    </testcase>
+    <testcase name="TestRefresh" classname="auth"><skipped/></testcase>
+  </testsuite>
+</testsuites>`
+
+	report, err := junit.Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Cases) != 3 {
+		t.Fatalf("expected 3 cases, got %d", len(report.Cases))
+	}
+	failed := report.Failed()
+	if len(failed) != 1 {
+		t.Fatalf("expected 1 failed case, got %d", len(failed))
+	}
+	f := failed[0]
+	if f.Suite != "auth" || f.Name != "TestLogout" {
+		t.Errorf("unexpected failed case: %+v", f)
+	}
+	if f.Message != "expected 200, got 500" {
+		t.Errorf("expected failure message, got %q", f.Message)
+	}
+	if !strings.Contains(f.StackTrace, "goroutine 7") {
+		t.Errorf("expected stack trace from failure body, got %q", f.StackTrace)
+	}
+	if f.Duration != 1500*time.Millisecond {
+		t.Errorf("expected 1.5s duration, got %s", f.Duration)
+	}
+	if report.Count(domain.TestSkipped) != 1 {
+		t.Errorf("expected 1 skipped case, got %d", report.Count(domain.TestSkipped))
+	}
+}
+
+func TestParse_SingleTestSuiteRootWithError(t *testing.T) {
+	doc := `<testsuite name="pkg"><testcase name="TestPanic"><error>panic: nil map
+main.go:10</error></testcase></testsuite>`
+
+	report, err := junit.Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Cases) != 1 || report.Cases[0].Status != domain.TestErrored {
+		t.Fatalf("expected 1 errored case, got %+v", report.Cases)
+	}
+	if report.Cases[0].Message != "panic: nil map" {
+		t.Errorf("expected message from first body line, got %q", report.Cases[0].Message)
+	}
+}
+
+func TestParse_NestedSuitesAreFlattened(t *testing.T) {
+	doc := `<testsuites><testsuite name="outer"><testsuite name="inner"><testcase name="a"/></testsuite></testsuite></testsuites>`
+
+	report, err := junit.Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Cases) != 1 || report.Cases[0].Suite != "inner" {
+		t.Errorf("expected nested case in suite 'inner', got %+v", report.Cases)
+	}
+}
+
+func TestParse_RejectsOtherXML(t *testing.T) {
+	_, err := junit.Parse(strings.NewReader(`<project><modelVersion>4.0.0</modelVersion></project>`))
+	if !errors.Is(err, junit.ErrNotJUnit) {
+		t.Errorf("expected ErrNotJUnit, got %v", err)
+	}
+}
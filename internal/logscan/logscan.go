@@ -0,0 +1,85 @@
+// Package logscan extracts likely failure causes from raw CI job logs.
+package logscan
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Rule matches log lines that indicate a failure.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// Finding is a log line matched by a rule.
+// Line is the zero-based index of the line in the log.
+type Finding struct {
+	Line int
+	Rule string
+	Text string
+}
+
+// timestampPrefix matches the RFC 3339 timestamp GitHub prepends to every log line.
+var timestampPrefix = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T[0-9:.]+Z\s?`)
+
+// ansiEscape matches terminal color and cursor escape sequences, which GitLab keeps in job traces.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// DefaultRules returns the built-in rules for common failure output.
+func DefaultRules() []Rule {
+	return []Rule{
+		{Name: "error", Pattern: regexp.MustCompile(`##\[error\]`)},
+		{Name: "test failure", Pattern: regexp.MustCompile(`^\s*--- FAIL: `)},
+		{Name: "compile error", Pattern: regexp.MustCompile(`^\S+\.\w+:\d+:\d+: `)},
+		{Name: "compile error", Pattern: regexp.MustCompile(`^error(\[\w+\])?: `)},
+		{Name: "panic", Pattern: regexp.MustCompile(`^panic: |^fatal error: |^Traceback \(most recent call last\)`)},
+		{Name: "exit code", Pattern: regexp.MustCompile(`(?i)(exit(ed)? (with )?(status|code) [1-9][0-9]*|ERROR: Job failed)`)},
+	}
+}
+
+// CompileRule builds a rule from a user-supplied regular expression.
+func CompileRule(name string, pattern string) (Rule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid pattern for log rule %q: %w", name, err)
+	}
+	return Rule{Name: name, Pattern: re}, nil
+}
+
+// Analyzer scans logs with a fixed set of rules.
+type Analyzer struct {
+	rules []Rule
+}
+
+// NewAnalyzer creates an Analyzer that applies rules in order.
+// Each line is reported at most once, under the first rule that matches it.
+func NewAnalyzer(rules []Rule) *Analyzer {
+	return &Analyzer{rules: rules}
+}
+
+// Analyze returns the findings in log order.
+// Timestamps and ANSI escape sequences are stripped before matching and from
+// the reported text.
+func (a *Analyzer) Analyze(log string) []Finding {
+	var findings []Finding
+	for i, raw := range strings.Split(log, "\n") {
+		line := Clean(raw)
+		for _, r := range a.rules {
+			if r.Pattern.MatchString(line) {
+				findings = append(findings, Finding{Line: i, Rule: r.Name, Text: strings.TrimSpace(line)})
+				break
+			}
+		}
+	}
+	return findings
+}
+
+// Clean strips the provider timestamp prefix, ANSI escape sequences and
+// trailing carriage returns from a log line.
+func Clean(line string) string {
+	line = strings.TrimRight(line, "\r")
+	line = ansiEscape.ReplaceAllString(line, "")
+	return timestampPrefix.ReplaceAllString(line, "")
+}
@@ -0,0 +1,68 @@
+package logscan_test
+
+import (
+	"testing"
+
+	"github.com/waabox/gitdeck/internal/logscan"
+)
+
+func TestAnalyze_DetectsDefaultFailurePatterns(t *testing.T) {
+	log := `2026-01-01T10:00:00.0000000Z Run go test ./...
+2026-01-01T10:00:01.0000000Z --- FAIL: TestLogin (0.01s)
+2026-01-01T10:00:01.0000000Z     auth_test.go:42: expected 200, got 500
+internal/app/main.go:12:5: undefined: foo
+panic: runtime error: index out of range [3] with length 3
+2026-01-01T10:00:02.0000000Z ##[error]Process completed with exit code 1.
+make: *** [test] Error 2
+ERROR: Job failed: exit code 1`
+
+	findings := logscan.NewAnalyzer(logscan.DefaultRules()).Analyze(log)
+
+	want := []struct {
+		line int
+		rule string
+	}{
+		{1, "test failure"},
+		{3, "compile error"},
+		{4, "panic"},
+		{5, "error"},
+		{7, "exit code"},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("expected %d findings, got %d: %+v", len(want), len(findings), findings)
+	}
+	for i, w := range want {
+		if findings[i].Line != w.line || findings[i].Rule != w.rule {
+			t.Errorf("finding %d: want line %d rule %q, got line %d rule %q",
+				i, w.line, w.rule, findings[i].Line, findings[i].Rule)
+		}
+	}
+	if findings[0].Text != "--- FAIL: TestLogin (0.01s)" {
+		t.Errorf("expected timestamp stripped from text, got %q", findings[0].Text)
+	}
+}
+
+func TestAnalyze_StripsANSIEscapes(t *testing.T) {
+	findings := logscan.NewAnalyzer(logscan.DefaultRules()).Analyze("\x1b[31;1mERROR: Job failed: exit code 2\x1b[0;m")
+	if len(findings) != 1 || findings[0].Text != "ERROR: Job failed: exit code 2" {
+		t.Errorf("expected 1 clean finding, got %+v", findings)
+	}
+}
+
+func TestAnalyze_FirstMatchingRuleWins(t *testing.T) {
+	custom, err := logscan.CompileRule("flaky", `--- FAIL: TestFlaky`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rules := append([]logscan.Rule{custom}, logscan.DefaultRules()...)
+	findings := logscan.NewAnalyzer(rules).Analyze("--- FAIL: TestFlaky (1s)")
+	if len(findings) != 1 || findings[0].Rule != "flaky" {
+		t.Errorf("expected custom rule to win, got %+v", findings)
+	}
+}
+
+func TestCompileRule_RejectsInvalidPattern(t *testing.T) {
+	if _, err := logscan.CompileRule("broken", `([`); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
@@ -0,0 +1,96 @@
+// Package network builds the HTTP client shared by the provider adapters and
+// the auth flows, so that proxy and TLS settings apply to every request.
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// DefaultTimeout bounds requests when Options.Timeout is not set.
+const DefaultTimeout = 15 * time.Second
+
+// Options configures the HTTP client. The zero value gives a client that
+// uses the proxy from the environment and the system certificate pool.
+type Options struct {
+	// ProxyURL overrides the HTTP_PROXY/HTTPS_PROXY environment variables.
+	ProxyURL string
+	// CABundle is a PEM file of certificates trusted in addition to the
+	// system pool, for instances signed by an internal CA.
+	CABundle string
+	// ClientCert and ClientKey are the PEM certificate and key presented for
+	// mutual TLS. Both or neither must be set.
+	ClientCert string
+	ClientKey  string
+	Timeout    time.Duration
+	// InsecureSkipVerify disables certificate verification. It makes
+	// connections open to interception and is meant for debugging only.
+	InsecureSkipVerify bool
+	// Trace, if set, receives a structured entry for every request.
+	Trace *slog.Logger
+}
+
+// NewClient creates an HTTP client configured by opts.
+func NewClient(opts Options) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.ProxyURL != "" {
+		proxy, err := url.Parse(opts.ProxyURL)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", opts.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}
+	if opts.CABundle != "" {
+		pool, err := certPool(opts.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if opts.ClientCert != "" || opts.ClientKey != "" {
+		if opts.ClientCert == "" || opts.ClientKey == "" {
+			return nil, fmt.Errorf("client certificate and key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	var roundTripper http.RoundTripper = transport
+	if opts.Trace != nil {
+		roundTripper = &tracingTransport{next: transport, logger: opts.Trace}
+	}
+	return &http.Client{Transport: roundTripper, Timeout: timeout}, nil
+}
+
+// certPool returns the system certificate pool extended with the
+// certificates in the PEM file at path.
+func certPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
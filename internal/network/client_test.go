@@ -0,0 +1,168 @@
+package network_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/network"
+)
+
+func TestNewClient_Defaults(t *testing.T) {
+	client, err := network.NewClient(network.Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.Timeout != network.DefaultTimeout {
+		t.Errorf("expected default timeout, got %v", client.Timeout)
+	}
+}
+
+func TestNewClient_TrustsCABundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	plain, _ := network.NewClient(network.Options{})
+	if _, err := plain.Get(srv.URL); err == nil {
+		t.Fatal("expected the self-signed certificate to be rejected without a CA bundle")
+	}
+
+	bundle := writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw)
+	client, err := network.NewClient(network.Options{CABundle: bundle})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("expected the CA bundle to be trusted, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewClient_InsecureSkipVerify(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client, err := network.NewClient(network.Options{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("expected verification to be skipped, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewClient_UsesProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	client, err := network.NewClient(network.Options{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := client.Get("http://gitlab.corp.example/api/v4/projects")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if proxied != "http://gitlab.corp.example/api/v4/projects" {
+		t.Errorf("expected the request to go through the proxy, got %q", proxied)
+	}
+}
+
+func TestNewClient_PresentsClientCertificate(t *testing.T) {
+	var presented int
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	certPath, keyPath := writeClientCertificate(t)
+	client, err := network.NewClient(network.Options{
+		ClientCert:         certPath,
+		ClientKey:          keyPath,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if presented != 1 {
+		t.Errorf("expected the client certificate to be presented, got %d certificates", presented)
+	}
+}
+
+func TestNewClient_RejectsInvalidOptions(t *testing.T) {
+	certPath, _ := writeClientCertificate(t)
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]network.Options{
+		"proxy without host": {ProxyURL: "proxy.corp:8080"},
+		"missing CA bundle":  {CABundle: filepath.Join(t.TempDir(), "missing.pem")},
+		"empty CA bundle":    {CABundle: empty},
+		"certificate only":   {ClientCert: certPath},
+	}
+	for name, opts := range cases {
+		if _, err := network.NewClient(opts); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// writeClientCertificate writes a self-signed certificate and its key and
+// returns their paths.
+func writeClientCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writePEM(t, "client.pem", "CERTIFICATE", der), writePEM(t, "client-key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,108 @@
+package network
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// rateLimitHeaders are the response headers traced for every request.
+var rateLimitHeaders = []string{
+	"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset",
+	"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset",
+	"Retry-After",
+}
+
+// sensitiveParams are query parameters whose values are credentials,
+// compared case-insensitively. Besides API tokens they cover the signatures
+// of the pre-signed storage URLs that log and artifact downloads redirect to.
+var sensitiveParams = []string{
+	"access_token", "private_token", "refresh_token", "token",
+	"sig", "signature", "x-amz-signature", "x-amz-credential", "x-amz-security-token",
+	"x-goog-signature", "x-goog-credential",
+}
+
+// redacted replaces credentials in the trace.
+const redacted = "[redacted]"
+
+// tracingTransport logs every request it sends: method, URL, status,
+// latency and rate-limit headers. Credentials are never logged.
+type tracingTransport struct {
+	next   http.RoundTripper
+	logger *slog.Logger
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	attrs := []any{
+		slog.String("method", req.Method),
+		slog.String("url", traceURL(req)),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		attrs = append(attrs, slog.String("authorization", redactAuthorization(auth)))
+	}
+	if err != nil {
+		t.logger.Error("http request", append(attrs, slog.String("error", err.Error()))...)
+		return nil, err
+	}
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	for _, h := range rateLimitHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			attrs = append(attrs, slog.String(strings.ToLower(h), v))
+		}
+	}
+	t.logger.Info("http request", attrs...)
+	return resp, nil
+}
+
+// redactAuthorization keeps the scheme of an Authorization header, such as
+// "Bearer", and drops the credentials.
+func redactAuthorization(value string) string {
+	if scheme, _, ok := strings.Cut(value, " "); ok {
+		return scheme + " " + redacted
+	}
+	return redacted
+}
+
+// traceURL renders the URL of req for the trace. Redirect hops lead to
+// pre-signed URLs whose query is a credential, so only their scheme, host
+// and path are logged.
+func traceURL(req *http.Request) string {
+	if req.Response != nil {
+		clean := url.URL{Scheme: req.URL.Scheme, Host: req.URL.Host, Path: req.URL.Path}
+		return clean.String()
+	}
+	return redactURL(req.URL)
+}
+
+// redactURL renders u without user info and with the values of credential
+// query parameters removed.
+func redactURL(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+	query := u.Query()
+	changed := false
+	for name := range query {
+		if isSensitiveParam(name) {
+			query.Set(name, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		clean.RawQuery = query.Encode()
+	}
+	return clean.String()
+}
+
+func isSensitiveParam(name string) bool {
+	for _, sensitive := range sensitiveParams {
+		if strings.EqualFold(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
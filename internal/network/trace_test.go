@@ -0,0 +1,107 @@
+package network_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/waabox/gitdeck/internal/network"
+)
+
+func TestTrace_LogsRequestsWithoutCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Remaining", "1999")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	client, err := network.NewClient(network.Options{Trace: slog.New(slog.NewJSONHandler(&buf, nil))})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v4/projects?private_token=s3cr3t&page=2", nil)
+	req.Header.Set("Authorization", "Bearer gho_s3cr3t")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if strings.Contains(buf.String(), "s3cr3t") {
+		t.Fatalf("expected credentials to be redacted, got %s", buf.String())
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON entry, got %q: %v", buf.String(), err)
+	}
+	want := map[string]interface{}{
+		"method":              "GET",
+		"url":                 srv.URL + "/api/v4/projects?page=2&private_token=%5Bredacted%5D",
+		"status":              float64(404),
+		"authorization":       "Bearer [redacted]",
+		"ratelimit-remaining": "1999",
+	}
+	for k, v := range want {
+		if entry[k] != v {
+			t.Errorf("expected %s = %v, got %v", k, v, entry[k])
+		}
+	}
+	if _, ok := entry["latency"]; !ok {
+		t.Error("expected latency to be logged")
+	}
+}
+
+func TestTrace_LogsFailedRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close()
+
+	var buf bytes.Buffer
+	client, _ := network.NewClient(network.Options{Trace: slog.New(slog.NewJSONHandler(&buf, nil))})
+	if _, err := client.Get(srv.URL); err == nil {
+		t.Fatal("expected the request to fail")
+	}
+	if !strings.Contains(buf.String(), `"level":"ERROR"`) || !strings.Contains(buf.String(), `"error":`) {
+		t.Errorf("expected an error entry, got %s", buf.String())
+	}
+}
+
+func TestTrace_RedactsSignedURLsAndUserInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/logs" {
+			http.Redirect(w, r, "/blob/job.log?sv=2021&sig=c2VjcmV0&se=2026", http.StatusFound)
+		}
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	client, _ := network.NewClient(network.Options{Trace: slog.New(slog.NewJSONHandler(&buf, nil))})
+	resp, err := client.Get(srv.URL + "/logs")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	signed := strings.Replace(srv.URL, "http://", "http://user:pa55@", 1) +
+		"/download?X-Amz-Credential=AKIA1234&X-Amz-Signature=c2VjcmV0&part=1"
+	resp, err = client.Get(signed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	for _, secret := range []string{"c2VjcmV0", "AKIA1234", "pa55", "sv=2021"} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("expected %q to be redacted, got %s", secret, buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), `"url":"`+srv.URL+`/blob/job.log"`) {
+		t.Errorf("expected the redirect hop logged without its query, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "part=1") {
+		t.Errorf("expected other query parameters to be kept, got %s", buf.String())
+	}
+}
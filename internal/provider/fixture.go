@@ -0,0 +1,101 @@
+// internal/provider/fixture.go
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// Fixture is a recorded session of provider calls, written by
+// RecordingProvider and served back by ReplayProvider.
+type Fixture struct {
+	// Repository is the repository the calls were made for.
+	Repository domain.Repository `json:"repository"`
+	// Calls are in the order they were made.
+	Calls []RecordedCall `json:"calls"`
+}
+
+// RecordedCall is the response to one provider call. Key identifies the
+// arguments, such as the pipeline ID; the repository is not part of it.
+type RecordedCall struct {
+	Method string          `json:"method"`
+	Key    string          `json:"key,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *RecordedError  `json:"error,omitempty"`
+}
+
+// RecordedError is a recorded error. Kind names the domain sentinel the
+// error wrapped, if any, so that replayed errors are handled the same way.
+type RecordedError struct {
+	Message string `json:"message"`
+	Kind    string `json:"kind,omitempty"`
+}
+
+// errorKinds are the domain sentinels preserved by recording.
+var errorKinds = map[string]error{
+	"not_supported": domain.ErrNotSupported,
+	"unauthorized":  domain.ErrUnauthorized,
+	"forbidden":     domain.ErrForbidden,
+	"not_found":     domain.ErrNotFound,
+	"conflict":      domain.ErrConflict,
+}
+
+func newRecordedError(err error) *RecordedError {
+	recorded := &RecordedError{Message: err.Error()}
+	for kind, sentinel := range errorKinds {
+		if errors.Is(err, sentinel) {
+			recorded.Kind = kind
+			break
+		}
+	}
+	return recorded
+}
+
+// replayedError reproduces a recorded error, wrapping its domain sentinel.
+type replayedError struct {
+	message string
+	kind    error
+}
+
+func (e *replayedError) Error() string {
+	return e.message
+}
+
+func (e *replayedError) Unwrap() error {
+	return e.kind
+}
+
+func (r *RecordedError) err() error {
+	return &replayedError{message: r.Message, kind: errorKinds[r.Kind]}
+}
+
+// LoadFixture reads a fixture written by RecordingProvider.Save.
+func LoadFixture(path string) (Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, fmt.Errorf("reading fixture: %w", err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return Fixture{}, fmt.Errorf("parsing fixture %s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// SaveFixture writes fixture to path, creating parent directories as needed.
+// Permissions on the written file are 0600, as it holds API responses.
+func SaveFixture(path string, fixture Fixture) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating fixture directory: %w", err)
+	}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding fixture: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
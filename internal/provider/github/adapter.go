@@ -1,30 +1,71 @@
 package github
 
 import (
-	"encoding/json"
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/waabox/gitdeck/internal/apiclient"
+	"github.com/waabox/gitdeck/internal/cron"
 	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/junit"
 )
 
 const defaultBaseURL = "https://api.github.com"
 
+// maxTestReportArtifactSize caps the size of artifacts scanned for JUnit reports,
+// so that large build outputs are never downloaded just to look for XML files,
+// and maxTestReportSize caps the bytes downloaded for one test report.
+const (
+	maxTestReportArtifactSize = 20 << 20
+	maxTestReportSize         = 50 << 20
+)
+
+// failedLookupTTL is how long a failed lookup of pull request details is
+// remembered, so that it is retried on a later refresh rather than on each one.
+const failedLookupTTL = time.Minute
+
+// testReportArtifactNames are the words that mark an artifact as likely to
+// hold JUnit reports, e.g. "test-results" or "junit-reports".
+var testReportArtifactNames = []string{"test", "junit", "report", "result"}
+
 // Adapter implements domain.PipelineProvider for GitHub Actions.
 type Adapter struct {
 	mu      sync.Mutex
-	token   string
+	api     *apiclient.Client
 	baseURL string
 	limit   int
-	client  *http.Client
+	// pullRequests caches pull request details by "owner/name#number", and
+	// failedLookups holds when fetching one last failed, by the same key.
+	pullRequests  map[string]domain.MergeRequest
+	failedLookups map[string]time.Time
 }
 
-// Ensure Adapter fully implements domain.PipelineProvider.
-var _ domain.PipelineProvider = (*Adapter)(nil)
+// Ensure Adapter fully implements domain.PipelineProvider and its optional capabilities.
+var (
+	_ domain.PipelineProvider             = (*Adapter)(nil)
+	_ domain.ArtifactProvider             = (*Adapter)(nil)
+	_ domain.TestReportProvider           = (*Adapter)(nil)
+	_ domain.AnnotationProvider           = (*Adapter)(nil)
+	_ domain.MergeRequestPipelineProvider = (*Adapter)(nil)
+	_ domain.WorkflowPipelineProvider     = (*Adapter)(nil)
+	_ domain.AttemptProvider              = (*Adapter)(nil)
+	_ domain.EnvironmentProvider          = (*Adapter)(nil)
+	_ domain.RunnerProvider               = (*Adapter)(nil)
+	_ domain.CommitCheckProvider          = (*Adapter)(nil)
+	_ domain.RateLimitReporter            = (*Adapter)(nil)
+	_ domain.ScheduleProvider             = (*Adapter)(nil)
+)
 
 // NewAdapter creates a GitHub Actions adapter.
 // baseURL is used for testing; pass empty string to use the real GitHub API.
@@ -34,18 +75,35 @@ func NewAdapter(token string, baseURL string, limit int) *Adapter {
 		baseURL = defaultBaseURL
 	}
 	return &Adapter{
-		token:   token,
+		api:     apiclient.New("github", token, map[string]string{"Accept": "application/vnd.github+json"}),
 		baseURL: baseURL,
 		limit:   limit,
-		client:  &http.Client{Timeout: 15 * time.Second},
+
+		pullRequests:  make(map[string]domain.MergeRequest),
+		failedLookups: make(map[string]time.Time),
 	}
 }
 
 // SetToken updates the access token used for API requests.
 func (a *Adapter) SetToken(token string) {
-	a.mu.Lock()
-	defer a.mu.Unlock()
-	a.token = token
+	a.api.SetToken(token)
+}
+
+// RateLimit returns the API request quota as of the last response.
+func (a *Adapter) RateLimit() (domain.RateLimit, bool) {
+	return a.api.RateLimit()
+}
+
+// SetHTTPClient replaces the HTTP client used for API requests, e.g. with one
+// configured for a proxy or a custom CA. It must be called before the adapter is used.
+func (a *Adapter) SetHTTPClient(client *http.Client) {
+	a.api.SetHTTPClient(client)
+}
+
+// SetCache replaces the in-memory response cache, e.g. with an on-disk one.
+// It must be called before the adapter is used.
+func (a *Adapter) SetCache(cache apiclient.Cache) {
+	a.api.SetCache(cache)
 }
 
 // ListPipelines returns the most recent workflow runs for the repository.
@@ -54,21 +112,100 @@ func (a *Adapter) ListPipelines(repo domain.Repository) ([]domain.Pipeline, erro
 	var result struct {
 		WorkflowRuns []workflowRun `json:"workflow_runs"`
 	}
-	if err := a.get(url, &result); err != nil {
+	if err := a.api.Get(url, &result); err != nil {
 		return nil, err
 	}
 	pipelines := make([]domain.Pipeline, len(result.WorkflowRuns))
 	for i, run := range result.WorkflowRuns {
 		pipelines[i] = run.toPipeline()
 	}
+	a.addPullRequestDetails(repo, pipelines, false)
 	return pipelines, nil
 }
 
+// ListWorkflowPipelines returns the most recent runs of a single workflow.
+func (a *Adapter) ListWorkflowPipelines(repo domain.Repository, workflowID string) ([]domain.Pipeline, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/workflows/%s/runs?per_page=%d",
+		a.baseURL, repo.Owner, repo.Name, workflowID, a.limit)
+	var result struct {
+		WorkflowRuns []workflowRun `json:"workflow_runs"`
+	}
+	if err := a.api.Get(url, &result); err != nil {
+		return nil, err
+	}
+	pipelines := make([]domain.Pipeline, len(result.WorkflowRuns))
+	for i, run := range result.WorkflowRuns {
+		pipelines[i] = run.toPipeline()
+	}
+	a.addPullRequestDetails(repo, pipelines, false)
+	return pipelines, nil
+}
+
+// ListMergeRequestPipelines returns the most recent workflow runs of a pull request.
+// Runs are looked up by the pull request's head branch and kept only if GitHub
+// associates them with the same pull request.
+func (a *Adapter) ListMergeRequestPipelines(repo domain.Repository, mr domain.MergeRequest) ([]domain.Pipeline, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs?branch=%s&per_page=%d",
+		a.baseURL, repo.Owner, repo.Name, neturl.QueryEscape(mr.SourceBranch), a.limit)
+	var result struct {
+		WorkflowRuns []workflowRun `json:"workflow_runs"`
+	}
+	if err := a.api.Get(url, &result); err != nil {
+		return nil, err
+	}
+	var pipelines []domain.Pipeline
+	for _, run := range result.WorkflowRuns {
+		p := run.toPipeline()
+		if p.MergeRequest != nil && p.MergeRequest.Number == mr.Number {
+			pipelines = append(pipelines, p)
+		}
+	}
+	a.addPullRequestDetails(repo, pipelines, false)
+	return pipelines, nil
+}
+
+// addPullRequestDetails fills in the title and draft state of the pull requests
+// the pipelines belong to. Runs only carry the number and branches of a pull
+// request, so each one is fetched once and cached; refresh fetches them again,
+// so that opening a pipeline picks up a new title or draft state. Lookup
+// failures are ignored, and retried after failedLookupTTL: the pipeline keeps
+// the data the run provided.
+func (a *Adapter) addPullRequestDetails(repo domain.Repository, pipelines []domain.Pipeline, refresh bool) {
+	for i := range pipelines {
+		if pipelines[i].MergeRequest == nil {
+			continue
+		}
+		number := pipelines[i].MergeRequest.Number
+		key := fmt.Sprintf("%s/%s#%d", repo.Owner, repo.Name, number)
+		a.mu.Lock()
+		mr, ok := a.pullRequests[key]
+		failed := time.Since(a.failedLookups[key]) < failedLookupTTL
+		a.mu.Unlock()
+		if refresh || (!ok && !failed) {
+			url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", a.baseURL, repo.Owner, repo.Name, number)
+			var pr pullRequest
+			err := a.api.Get(url, &pr)
+			a.mu.Lock()
+			if err != nil {
+				a.failedLookups[key] = time.Now()
+			} else {
+				mr, ok = pr.toMergeRequest(), true
+				a.pullRequests[key] = mr
+				delete(a.failedLookups, key)
+			}
+			a.mu.Unlock()
+		}
+		if ok {
+			pipelines[i].MergeRequest = &mr
+		}
+	}
+}
+
 // GetPipeline returns a single workflow run with all its jobs.
 func (a *Adapter) GetPipeline(repo domain.Repository, id domain.PipelineID) (domain.Pipeline, error) {
 	runURL := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%s", a.baseURL, repo.Owner, repo.Name, id)
 	var run workflowRun
-	if err := a.get(runURL, &run); err != nil {
+	if err := a.api.Get(runURL, &run); err != nil {
 		return domain.Pipeline{}, err
 	}
 
@@ -76,7 +213,35 @@ func (a *Adapter) GetPipeline(repo domain.Repository, id domain.PipelineID) (dom
 	var jobsResult struct {
 		Jobs []workflowJob `json:"jobs"`
 	}
-	if err := a.get(jobsURL, &jobsResult); err != nil {
+	if err := a.api.Get(jobsURL, &jobsResult); err != nil {
+		return domain.Pipeline{}, err
+	}
+
+	enriched := []domain.Pipeline{run.toPipeline()}
+	a.addPullRequestDetails(repo, enriched, true)
+	pipeline := enriched[0]
+	pipeline.Jobs = make([]domain.Job, len(jobsResult.Jobs))
+	for i, j := range jobsResult.Jobs {
+		pipeline.Jobs[i] = j.toJob()
+	}
+	return pipeline, nil
+}
+
+// GetPipelineAttempt returns an earlier attempt of a rerun workflow run with
+// the jobs that ran in it. Job IDs are unique per attempt, so the logs of
+// those jobs are fetched with GetJobLogs as usual.
+func (a *Adapter) GetPipelineAttempt(repo domain.Repository, id domain.PipelineID, attempt int) (domain.Pipeline, error) {
+	runURL := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%s/attempts/%d", a.baseURL, repo.Owner, repo.Name, id, attempt)
+	var run workflowRun
+	if err := a.api.Get(runURL, &run); err != nil {
+		return domain.Pipeline{}, err
+	}
+
+	jobsURL := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%s/attempts/%d/jobs", a.baseURL, repo.Owner, repo.Name, id, attempt)
+	var jobsResult struct {
+		Jobs []workflowJob `json:"jobs"`
+	}
+	if err := a.api.Get(jobsURL, &jobsResult); err != nil {
 		return domain.Pipeline{}, err
 	}
 
@@ -88,123 +253,522 @@ func (a *Adapter) GetPipeline(repo domain.Repository, id domain.PipelineID) (dom
 	return pipeline, nil
 }
 
-func (a *Adapter) get(url string, target interface{}) error {
-	a.mu.Lock()
-	token := a.token
-	a.mu.Unlock()
+// RerunPipeline triggers a new run of the given workflow run.
+func (a *Adapter) RerunPipeline(repo domain.Repository, id domain.PipelineID) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%s/rerun",
+		a.baseURL, repo.Owner, repo.Name, id)
+	return a.api.Post(url)
+}
+
+// CancelPipeline cancels a running workflow run.
+func (a *Adapter) CancelPipeline(repo domain.Repository, id domain.PipelineID) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%s/cancel",
+		a.baseURL, repo.Owner, repo.Name, id)
+	return a.api.Post(url)
+}
+
+// GetJobLogs returns the full raw log text for the given job.
+// GitHub returns a 302 redirect to a pre-signed S3 URL; the HTTP client
+// follows it automatically and strips the Authorization header on the redirect.
+func (a *Adapter) GetJobLogs(repo domain.Repository, jobID domain.JobID) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/jobs/%s/logs",
+		a.baseURL, repo.Owner, repo.Name, jobID)
+	return a.api.GetText(url)
+}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// ListArtifacts returns the artifacts uploaded by the given workflow run.
+func (a *Adapter) ListArtifacts(repo domain.Repository, id domain.PipelineID) ([]domain.Artifact, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%s/artifacts?per_page=100",
+		a.baseURL, repo.Owner, repo.Name, id)
+	type page struct {
+		Artifacts []workflowArtifact `json:"artifacts"`
+	}
+	raw, err := apiclient.GetAllWrapped(a.api, url, func(p page) []workflowArtifact { return p.Artifacts })
 	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+		return nil, err
+	}
+	artifacts := make([]domain.Artifact, len(raw))
+	for i, art := range raw {
+		artifacts[i] = art.toArtifact()
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/vnd.github+json")
+	return artifacts, nil
+}
 
-	resp, err := a.client.Do(req)
+// DownloadArtifact streams the zip archive of the given artifact to dst.
+func (a *Adapter) DownloadArtifact(repo domain.Repository, artifact domain.Artifact, dst io.Writer, progress func(written, total int64)) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/artifacts/%s/zip",
+		a.baseURL, repo.Owner, repo.Name, artifact.ID)
+	return a.api.Download(url, dst, artifact.Size, progress)
+}
+
+// GetTestReport builds a test report from the JUnit XML files found in the
+// run's artifacts. GitHub has no test report API, so the artifacts whose name
+// suggests test results are downloaded, up to maxTestReportArtifactSize each
+// and maxTestReportSize in total, and their *.xml entries parsed. Files that
+// are not JUnit reports are ignored; artifacts that are skipped or cannot be
+// read are listed in the report's warnings.
+func (a *Adapter) GetTestReport(repo domain.Repository, id domain.PipelineID) (domain.TestReport, error) {
+	artifacts, err := a.ListArtifacts(repo, id)
 	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+		return domain.TestReport{}, err
+	}
+	var report domain.TestReport
+	var total int64
+	for _, art := range artifacts {
+		if art.Expired || !isTestReportArtifact(art.Name) {
+			continue
+		}
+		if art.Size > maxTestReportArtifactSize || total+art.Size > maxTestReportSize {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("artifact %s skipped: too large (%d bytes)", art.Name, art.Size))
+			continue
+		}
+		total += art.Size
+		cases, err := a.artifactTestCases(repo, art)
+		if abortsListing(err) {
+			return domain.TestReport{}, err
+		}
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("artifact %s skipped: %v", art.Name, err))
+			continue
+		}
+		report.Cases = append(report.Cases, cases...)
+	}
+	return report, nil
+}
+
+// isTestReportArtifact reports whether the artifact name suggests test results.
+func isTestReportArtifact(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range testReportArtifactNames {
+		if strings.Contains(name, word) {
+			return true
+		}
 	}
-	defer resp.Body.Close()
+	return false
+}
 
-	if resp.StatusCode == http.StatusUnauthorized {
-		return fmt.Errorf("github API error: %s: %w", resp.Status, domain.ErrUnauthorized)
+// artifactTestCases downloads the artifact and parses the JUnit reports in it.
+func (a *Adapter) artifactTestCases(repo domain.Repository, art domain.Artifact) ([]domain.TestCase, error) {
+	var buf bytes.Buffer
+	if err := a.DownloadArtifact(repo, art, &buf, nil); err != nil {
+		return nil, err
 	}
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("github API error: %s", resp.Status)
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		return nil, err
+	}
+	var cases []domain.TestCase
+	for _, f := range archive.File {
+		if !strings.HasSuffix(strings.ToLower(f.Name), ".xml") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", f.Name, err)
+		}
+		parsed, err := junit.Parse(rc)
+		rc.Close()
+		if err != nil {
+			continue
+		}
+		cases = append(cases, parsed.Cases...)
 	}
-	return json.NewDecoder(resp.Body).Decode(target)
+	return cases, nil
 }
 
-// getText fetches a URL and returns the response body as a plain string.
-// It follows redirects using Go's default policy, which strips the Authorization
-// header on cross-domain redirects — the correct behaviour for GitHub's log
-// endpoint that returns a 302 redirect to a pre-signed S3 URL.
-func (a *Adapter) getText(url string) (string, error) {
-	a.mu.Lock()
-	token := a.token
-	a.mu.Unlock()
+// GetJobAnnotations returns the annotations of the check run backing the given job.
+// GitHub Actions jobs are check runs, so the job ID doubles as the check run ID.
+func (a *Adapter) GetJobAnnotations(repo domain.Repository, jobID domain.JobID) ([]domain.Annotation, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/check-runs/%s/annotations?per_page=100",
+		a.baseURL, repo.Owner, repo.Name, jobID)
+	var raw []checkRunAnnotation
+	if err := a.api.Get(url, &raw); err != nil {
+		return nil, err
+	}
+	annotations := make([]domain.Annotation, len(raw))
+	for i, r := range raw {
+		annotations[i] = r.toAnnotation()
+	}
+	return annotations, nil
+}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
+// ListCommitChecks returns everything reported against the commit: check
+// runs, the check suites grouping them per app, and the latest commit status
+// of each context. Suites in which the app created no check runs are skipped,
+// since GitHub creates one for every installed app and they never report.
+func (a *Adapter) ListCommitChecks(repo domain.Repository, sha string) ([]domain.CommitCheck, error) {
+	runsURL := fmt.Sprintf("%s/repos/%s/%s/commits/%s/check-runs?per_page=100", a.baseURL, repo.Owner, repo.Name, sha)
+	var runs struct {
+		CheckRuns []struct {
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			HTMLURL    string `json:"html_url"`
+			App        struct {
+				Name string `json:"name"`
+			} `json:"app"`
+			Output struct {
+				Title string `json:"title"`
+			} `json:"output"`
+		} `json:"check_runs"`
+	}
+	if err := a.api.Get(runsURL, &runs); err != nil {
+		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/vnd.github+json")
 
-	resp, err := a.client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("executing request: %w", err)
+	suitesURL := fmt.Sprintf("%s/repos/%s/%s/commits/%s/check-suites?per_page=100", a.baseURL, repo.Owner, repo.Name, sha)
+	var suites struct {
+		CheckSuites []struct {
+			Status               string `json:"status"`
+			Conclusion           string `json:"conclusion"`
+			LatestCheckRunsCount int    `json:"latest_check_runs_count"`
+			App                  struct {
+				Name string `json:"name"`
+			} `json:"app"`
+		} `json:"check_suites"`
+	}
+	if err := a.api.Get(suitesURL, &suites); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusUnauthorized {
-		return "", fmt.Errorf("github API error: %s: %w", resp.Status, domain.ErrUnauthorized)
+	// The combined status holds only the latest status of each context.
+	statusURL := fmt.Sprintf("%s/repos/%s/%s/commits/%s/status?per_page=100", a.baseURL, repo.Owner, repo.Name, sha)
+	var combined struct {
+		Statuses []struct {
+			Context     string `json:"context"`
+			State       string `json:"state"`
+			Description string `json:"description"`
+			TargetURL   string `json:"target_url"`
+		} `json:"statuses"`
 	}
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("github API error: %s", resp.Status)
+	if err := a.api.Get(statusURL, &combined); err != nil {
+		return nil, err
 	}
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("reading log response: %w", err)
+
+	var checks []domain.CommitCheck
+	for _, s := range suites.CheckSuites {
+		if s.LatestCheckRunsCount == 0 {
+			continue
+		}
+		checks = append(checks, domain.CommitCheck{
+			Name:        s.App.Name,
+			Kind:        domain.CheckSuite,
+			Source:      s.App.Name,
+			Status:      mapGitHubStatus(s.Status, s.Conclusion),
+			Description: fmt.Sprintf("%d check runs", s.LatestCheckRunsCount),
+		})
+	}
+	for _, r := range runs.CheckRuns {
+		checks = append(checks, domain.CommitCheck{
+			Name:        r.Name,
+			Kind:        domain.CheckRun,
+			Source:      r.App.Name,
+			Status:      mapGitHubStatus(r.Status, r.Conclusion),
+			Description: r.Output.Title,
+			URL:         r.HTMLURL,
+		})
+	}
+	for _, s := range combined.Statuses {
+		checks = append(checks, domain.CommitCheck{
+			Name:        s.Context,
+			Kind:        domain.CommitStatus,
+			Status:      mapCommitStatusState(s.State),
+			Description: s.Description,
+			URL:         s.TargetURL,
+		})
 	}
-	return string(b), nil
+	return checks, nil
 }
 
-// post sends a POST request with no body and discards the response body.
-// GitHub mutation endpoints (rerun, cancel) return 204 or 202 with no meaningful body.
-func (a *Adapter) post(url string) error {
-	a.mu.Lock()
-	token := a.token
-	a.mu.Unlock()
+// ListRunners returns the repository's self-hosted runners. GitHub-hosted
+// runners are not listed, and the endpoint requires admin access.
+func (a *Adapter) ListRunners(repo domain.Repository) ([]domain.Runner, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/runners?per_page=100", a.baseURL, repo.Owner, repo.Name)
+	var result struct {
+		Runners []struct {
+			ID     int64  `json:"id"`
+			Name   string `json:"name"`
+			Status string `json:"status"`
+			Busy   bool   `json:"busy"`
+			Labels []struct {
+				Name string `json:"name"`
+			} `json:"labels"`
+		} `json:"runners"`
+	}
+	if err := a.api.Get(url, &result); err != nil {
+		return nil, err
+	}
+	runners := make([]domain.Runner, len(result.Runners))
+	for i, r := range result.Runners {
+		labels := make([]string, len(r.Labels))
+		for l, label := range r.Labels {
+			labels[l] = label.Name
+		}
+		runners[i] = domain.Runner{
+			ID:     strconv.FormatInt(r.ID, 10),
+			Name:   r.Name,
+			Labels: labels,
+			Online: r.Status == "online",
+			Busy:   r.Busy,
+			Scope:  "repository",
+		}
+	}
+	return runners, nil
+}
 
-	req, err := http.NewRequest(http.MethodPost, url, nil)
-	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+// ListEnvironments returns the repository's environments with their last deployment.
+func (a *Adapter) ListEnvironments(repo domain.Repository) ([]domain.Environment, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/environments", a.baseURL, repo.Owner, repo.Name)
+	var result struct {
+		Environments []struct {
+			Name string `json:"name"`
+		} `json:"environments"`
+	}
+	if err := a.api.Get(url, &result); err != nil {
+		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/vnd.github+json")
+	environments := make([]domain.Environment, len(result.Environments))
+	for i, env := range result.Environments {
+		deployment, err := a.lastDeployment(repo, env.Name)
+		if err != nil {
+			return nil, err
+		}
+		environments[i] = domain.Environment{Name: env.Name, LastDeployment: deployment}
+	}
+	return environments, nil
+}
 
-	resp, err := a.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+// lastDeployment returns the most recent deployment to an environment with
+// its latest status, or nil if the environment has never been deployed to.
+func (a *Adapter) lastDeployment(repo domain.Repository, environment string) (*domain.Deployment, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/deployments?environment=%s&per_page=1",
+		a.baseURL, repo.Owner, repo.Name, neturl.QueryEscape(environment))
+	var deployments []githubDeployment
+	if err := a.api.Get(url, &deployments); err != nil {
+		return nil, err
+	}
+	if len(deployments) == 0 {
+		return nil, nil
 	}
-	defer resp.Body.Close()
+	d := deployments[0]
 
-	if resp.StatusCode == http.StatusUnauthorized {
-		return fmt.Errorf("github API error: %s: %w", resp.Status, domain.ErrUnauthorized)
+	statusesURL := fmt.Sprintf("%s/repos/%s/%s/deployments/%d/statuses?per_page=1", a.baseURL, repo.Owner, repo.Name, d.ID)
+	var statuses []deploymentStatus
+	if err := a.api.Get(statusesURL, &statuses); err != nil {
+		return nil, err
 	}
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("github API error: %s", resp.Status)
+	deployment := d.toDeployment()
+	if len(statuses) > 0 {
+		deployment.Status = mapDeploymentState(statuses[0].State)
+		deployment.PipelineID = runIDFromURL(statuses[0].LogURL)
+		if deployment.PipelineID == "" {
+			deployment.PipelineID = runIDFromURL(statuses[0].TargetURL)
+		}
 	}
-	return nil
+	return &deployment, nil
 }
 
-// RerunPipeline triggers a new run of the given workflow run.
-func (a *Adapter) RerunPipeline(repo domain.Repository, id domain.PipelineID) error {
-	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%s/rerun",
-		a.baseURL, repo.Owner, repo.Name, id)
-	return a.post(url)
+// runURL matches the workflow run ID in links such as
+// https://github.com/owner/repo/actions/runs/123/job/456.
+var runURL = regexp.MustCompile(`/actions/runs/(\d+)`)
+
+// runIDFromURL returns the workflow run a deployment status links to, or "".
+func runIDFromURL(url string) string {
+	if match := runURL.FindStringSubmatch(url); match != nil {
+		return match[1]
+	}
+	return ""
 }
 
-// CancelPipeline cancels a running workflow run.
-func (a *Adapter) CancelPipeline(repo domain.Repository, id domain.PipelineID) error {
-	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%s/cancel",
-		a.baseURL, repo.Owner, repo.Name, id)
-	return a.post(url)
+// githubDeployment is the raw GitHub API response shape for a deployment.
+type githubDeployment struct {
+	ID      int64  `json:"id"`
+	Ref     string `json:"ref"`
+	SHA     string `json:"sha"`
+	Creator struct {
+		Login string `json:"login"`
+	} `json:"creator"`
+	CreatedAt string `json:"created_at"`
 }
 
-// GetJobLogs returns the full raw log text for the given job.
-// GitHub returns a 302 redirect to a pre-signed S3 URL; the HTTP client
-// follows it automatically and strips the Authorization header on the redirect.
-func (a *Adapter) GetJobLogs(repo domain.Repository, jobID domain.JobID) (string, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s/actions/jobs/%s/logs",
-		a.baseURL, repo.Owner, repo.Name, jobID)
-	return a.getText(url)
+func (d githubDeployment) toDeployment() domain.Deployment {
+	created, _ := time.Parse(time.RFC3339, d.CreatedAt)
+	return domain.Deployment{
+		ID:        strconv.FormatInt(d.ID, 10),
+		Ref:       d.Ref,
+		CommitSHA: d.SHA,
+		Status:    domain.StatusPending,
+		Author:    d.Creator.Login,
+		CreatedAt: created,
+	}
+}
+
+// deploymentStatus is the raw GitHub API response shape for a deployment status.
+type deploymentStatus struct {
+	State     string `json:"state"`
+	LogURL    string `json:"log_url"`
+	TargetURL string `json:"target_url"`
+}
+
+// mapDeploymentState maps a GitHub deployment status state to a domain status.
+func mapDeploymentState(state string) domain.PipelineStatus {
+	switch state {
+	case "success":
+		return domain.StatusSuccess
+	case "failure", "error":
+		return domain.StatusFailed
+	case "in_progress":
+		return domain.StatusRunning
+	case "inactive":
+		// Superseded by a later deployment to the same environment.
+		return domain.StatusCancelled
+	default:
+		return domain.StatusPending
+	}
+}
+
+// maxScheduleWorkflows bounds how many workflow files ListSchedules reads.
+const maxScheduleWorkflows = 30
+
+// ListSchedules returns the workflows that have schedule triggers.
+// GitHub does not expose schedules through the API, so the cron expressions
+// are read from each workflow file and the next run is computed from them.
+// That costs one request per workflow file, for at most maxScheduleWorkflows
+// files, plus one per scheduled workflow for its last run; repeated opens are
+// conditional requests answered from the cache. Workflows whose file or last
+// run cannot be read are skipped or shown without their last run, so only
+// failing to list the workflows, an expired token or a rate limit fail the view.
+func (a *Adapter) ListSchedules(repo domain.Repository) ([]domain.Schedule, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/actions/workflows?per_page=100", a.baseURL, repo.Owner, repo.Name)
+	var result struct {
+		Workflows []struct {
+			ID    int64  `json:"id"`
+			Name  string `json:"name"`
+			Path  string `json:"path"`
+			State string `json:"state"`
+		} `json:"workflows"`
+	}
+	if err := a.api.Get(url, &result); err != nil {
+		return nil, err
+	}
+	var schedules []domain.Schedule
+	read := 0
+	for _, wf := range result.Workflows {
+		if !strings.HasPrefix(wf.Path, ".github/workflows/") {
+			// Dynamic workflows such as Dependabot have no file to read.
+			continue
+		}
+		if wf.State == "deleted" || wf.State == "disabled_fork" {
+			// The file is gone, or the workflow never runs in this fork.
+			continue
+		}
+		if read == maxScheduleWorkflows {
+			break
+		}
+		read++
+		content, err := a.fileContent(repo, wf.Path)
+		if err != nil {
+			if abortsListing(err) {
+				return nil, err
+			}
+			// Renamed or removed on the default branch since it last ran.
+			continue
+		}
+		crons := scheduleCrons(content)
+		if len(crons) == 0 {
+			continue
+		}
+		schedule := domain.Schedule{
+			ID:          strconv.FormatInt(wf.ID, 10),
+			Description: wf.Name,
+			Cron:        strings.Join(crons, ", "),
+			Active:      wf.State == "active",
+		}
+		if schedule.Active {
+			schedule.NextRunAt = nextCronRun(crons, time.Now().UTC())
+		}
+
+		runsURL := fmt.Sprintf("%s/repos/%s/%s/actions/workflows/%d/runs?event=schedule&per_page=1",
+			a.baseURL, repo.Owner, repo.Name, wf.ID)
+		var runs struct {
+			WorkflowRuns []workflowRun `json:"workflow_runs"`
+		}
+		if err := a.api.Get(runsURL, &runs); err != nil && abortsListing(err) {
+			return nil, err
+		}
+		if len(runs.WorkflowRuns) > 0 {
+			last := runs.WorkflowRuns[0].toPipeline()
+			schedule.Ref = last.Branch
+			schedule.LastStatus = last.Status
+			schedule.LastPipelineID = last.ID
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
+// abortsListing reports whether err, from a lookup about one item of a list,
+// applies to every other item too, so that skipping the item is pointless.
+func abortsListing(err error) bool {
+	var rateErr *domain.RateLimitedError
+	return errors.Is(err, domain.ErrUnauthorized) || errors.As(err, &rateErr)
+}
+
+// fileContent returns the content of a file on the repository's default branch.
+func (a *Adapter) fileContent(repo domain.Repository, path string) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/contents/%s", a.baseURL, repo.Owner, repo.Name, path)
+	var file struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := a.api.Get(url, &file); err != nil {
+		return "", err
+	}
+	if file.Encoding != "base64" {
+		return file.Content, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return "", fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return string(decoded), nil
+}
+
+// cronLine matches a schedule entry of a workflow file, such as "- cron: '0 3 * * *'".
+var cronLine = regexp.MustCompile(`(?m)^\s*-?\s*cron:\s*['"]?([^'"#\n]+?)['"]?\s*(#.*)?$`)
+
+// scheduleCrons returns the cron expressions of a workflow file's schedule trigger.
+func scheduleCrons(workflow string) []string {
+	var crons []string
+	for _, match := range cronLine.FindAllStringSubmatch(workflow, -1) {
+		crons = append(crons, strings.TrimSpace(match[1]))
+	}
+	return crons
+}
+
+// nextCronRun returns the earliest next run among the cron expressions.
+// GitHub evaluates schedules in UTC. Invalid expressions are ignored.
+func nextCronRun(crons []string, now time.Time) time.Time {
+	var next time.Time
+	for _, expr := range crons {
+		schedule, err := cron.Parse(expr)
+		if err != nil {
+			continue
+		}
+		if t := schedule.Next(now); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next
 }
 
 // workflowRun is the raw GitHub API response shape for a workflow run.
 type workflowRun struct {
 	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	WorkflowID int64  `json:"workflow_id"`
+	Event      string `json:"event"`
+	RunAttempt int    `json:"run_attempt"`
 	HeadBranch string `json:"head_branch"`
 	HeadSHA    string `json:"head_sha"`
 	HeadCommit struct {
@@ -213,10 +777,40 @@ type workflowRun struct {
 			Name string `json:"name"`
 		} `json:"author"`
 	} `json:"head_commit"`
-	Status     string `json:"status"`
-	Conclusion string `json:"conclusion"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
+	Status       string           `json:"status"`
+	Conclusion   string           `json:"conclusion"`
+	CreatedAt    string           `json:"created_at"`
+	UpdatedAt    string           `json:"updated_at"`
+	PullRequests []runPullRequest `json:"pull_requests"`
+}
+
+// runPullRequest is the minimal pull request reference embedded in a workflow run.
+type runPullRequest struct {
+	Number int `json:"number"`
+	Head   struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+// pullRequest is the raw GitHub API response shape for a pull request.
+type pullRequest struct {
+	runPullRequest
+	Title string `json:"title"`
+	Draft bool   `json:"draft"`
+}
+
+func (p pullRequest) toMergeRequest() domain.MergeRequest {
+	return domain.MergeRequest{
+		Kind:         domain.KindPullRequest,
+		Number:       p.Number,
+		Title:        p.Title,
+		SourceBranch: p.Head.Ref,
+		TargetBranch: p.Base.Ref,
+		Draft:        p.Draft,
+	}
 }
 
 func (r workflowRun) toPipeline() domain.Pipeline {
@@ -226,7 +820,7 @@ func (r workflowRun) toPipeline() domain.Pipeline {
 	if !created.IsZero() && !updated.IsZero() {
 		duration = updated.Sub(created)
 	}
-	return domain.Pipeline{
+	pipeline := domain.Pipeline{
 		ID:        strconv.FormatInt(r.ID, 10),
 		Branch:    r.HeadBranch,
 		CommitSHA: r.HeadSHA,
@@ -235,7 +829,23 @@ func (r workflowRun) toPipeline() domain.Pipeline {
 		Status:    mapGitHubStatus(r.Status, r.Conclusion),
 		CreatedAt: created,
 		Duration:  duration,
+		Workflow:  r.Name,
+		Event:     r.Event,
+		Attempt:   r.RunAttempt,
+	}
+	if r.WorkflowID != 0 {
+		pipeline.WorkflowID = strconv.FormatInt(r.WorkflowID, 10)
+	}
+	if len(r.PullRequests) > 0 {
+		pr := r.PullRequests[0]
+		pipeline.MergeRequest = &domain.MergeRequest{
+			Kind:         domain.KindPullRequest,
+			Number:       pr.Number,
+			SourceBranch: pr.Head.Ref,
+			TargetBranch: pr.Base.Ref,
+		}
 	}
+	return pipeline
 }
 
 // workflowStep is the raw GitHub API response shape for a job step.
@@ -253,12 +863,17 @@ type workflowJob struct {
 	Name        string         `json:"name"`
 	Status      string         `json:"status"`
 	Conclusion  string         `json:"conclusion"`
+	CreatedAt   string         `json:"created_at"`
 	StartedAt   string         `json:"started_at"`
 	CompletedAt string         `json:"completed_at"`
+	RunAttempt  int            `json:"run_attempt"`
+	RunnerName  string         `json:"runner_name"`
+	Labels      []string       `json:"labels"`
 	Steps       []workflowStep `json:"steps"`
 }
 
 func (j workflowJob) toJob() domain.Job {
+	queued, _ := time.Parse(time.RFC3339, j.CreatedAt)
 	started, _ := time.Parse(time.RFC3339, j.StartedAt)
 	completed, _ := time.Parse(time.RFC3339, j.CompletedAt)
 	var duration time.Duration
@@ -279,13 +894,85 @@ func (j workflowJob) toJob() domain.Job {
 			Duration: stepDuration,
 		}
 	}
+	status := mapGitHubStatus(j.Status, j.Conclusion)
+	if j.Status == "queued" || j.Status == "waiting" {
+		// Unlike a run, a job that is queued has not been picked up by a runner
+		// yet; any started_at GitHub reports for it is not when it started.
+		status = domain.StatusPending
+		started = time.Time{}
+	}
 	return domain.Job{
-		ID:        strconv.FormatInt(j.ID, 10),
-		Name:      j.Name,
-		Status:    mapGitHubStatus(j.Status, j.Conclusion),
-		StartedAt: started,
-		Duration:  duration,
-		Steps:     steps,
+		ID:           strconv.FormatInt(j.ID, 10),
+		Name:         j.Name,
+		Status:       status,
+		QueuedAt:     queued,
+		StartedAt:    started,
+		Duration:     duration,
+		Steps:        steps,
+		Attempt:      j.RunAttempt,
+		Runner:       j.RunnerName,
+		RunnerLabels: j.Labels,
+	}
+}
+
+// workflowArtifact is the raw GitHub API response shape for a run artifact.
+type workflowArtifact struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	SizeInBytes int64  `json:"size_in_bytes"`
+	Expired     bool   `json:"expired"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+func (a workflowArtifact) toArtifact() domain.Artifact {
+	expires, _ := time.Parse(time.RFC3339, a.ExpiresAt)
+	return domain.Artifact{
+		ID:        strconv.FormatInt(a.ID, 10),
+		Name:      a.Name,
+		Size:      a.SizeInBytes,
+		ExpiresAt: expires,
+		Expired:   a.Expired,
+	}
+}
+
+// checkRunAnnotation is the raw GitHub API response shape for a check run annotation.
+type checkRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+}
+
+func (r checkRunAnnotation) toAnnotation() domain.Annotation {
+	level := domain.AnnotationNotice
+	switch r.AnnotationLevel {
+	case "failure":
+		level = domain.AnnotationFailure
+	case "warning":
+		level = domain.AnnotationWarning
+	}
+	return domain.Annotation{
+		Path:      r.Path,
+		StartLine: r.StartLine,
+		EndLine:   r.EndLine,
+		Level:     level,
+		Title:     r.Title,
+		Message:   r.Message,
+	}
+}
+
+// mapCommitStatusState maps the state of a commit status, which external CI
+// systems report as pending until they finish.
+func mapCommitStatusState(state string) domain.PipelineStatus {
+	switch state {
+	case "success":
+		return domain.StatusSuccess
+	case "failure", "error":
+		return domain.StatusFailed
+	default:
+		return domain.StatusPending
 	}
 }
 
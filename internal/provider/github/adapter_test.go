@@ -1,11 +1,15 @@
 package github_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -367,3 +371,773 @@ func TestSetToken_UpdatesTokenForSubsequentRequests(t *testing.T) {
 		t.Errorf("second request: want 'Bearer new-token', got '%s'", receivedTokens[1])
 	}
 }
+
+func TestGetPipeline_ParsesJobQueuedAt(t *testing.T) {
+	queued := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/actions/runs/1001":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": float64(1001), "status": "completed", "conclusion": "success"})
+		case "/repos/waabox/gitdeck/actions/runs/1001/jobs":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"jobs": []map[string]interface{}{{
+					"id":           float64(2001),
+					"name":         "build",
+					"status":       "completed",
+					"conclusion":   "success",
+					"created_at":   queued.Format(time.RFC3339),
+					"started_at":   queued.Add(20 * time.Second).Format(time.RFC3339),
+					"completed_at": queued.Add(80 * time.Second).Format(time.RFC3339),
+				}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	pipeline, err := adapter.GetPipeline(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "1001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job := pipeline.Jobs[0]
+	if !job.QueuedAt.Equal(queued) {
+		t.Errorf("expected QueuedAt %s, got %s", queued, job.QueuedAt)
+	}
+	if job.StartedAt.Sub(job.QueuedAt) != 20*time.Second {
+		t.Errorf("expected 20s queue time, got %s", job.StartedAt.Sub(job.QueuedAt))
+	}
+}
+
+func TestGetPipeline_QueuedJobIsPendingAndCountsQueueTime(t *testing.T) {
+	queued := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/actions/runs/1001":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": float64(1001), "status": "queued"})
+		case "/repos/waabox/gitdeck/actions/runs/1001/jobs":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"jobs": []map[string]interface{}{{
+					"id":         float64(2001),
+					"name":       "build",
+					"status":     "queued",
+					"created_at": queued.Format(time.RFC3339),
+					"started_at": queued.Format(time.RFC3339),
+				}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	pipeline, err := adapter.GetPipeline(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "1001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job := pipeline.Jobs[0]
+	if job.Status != domain.StatusPending {
+		t.Errorf("expected a queued job to be pending, got %s", job.Status)
+	}
+	if got := job.QueuedDuration(queued.Add(3 * time.Minute)); got != 3*time.Minute {
+		t.Errorf("expected 3m queued, got %s", got)
+	}
+}
+
+func TestListArtifacts_ReturnsRunArtifacts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/waabox/gitdeck/actions/runs/1001/artifacts" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"artifacts": []map[string]interface{}{{
+					"id":            float64(5001),
+					"name":          "test-report",
+					"size_in_bytes": float64(2048),
+					"expired":       false,
+					"expires_at":    "2026-04-01T00:00:00Z",
+				}},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	artifacts, err := adapter.ListArtifacts(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "1001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(artifacts) != 1 {
+		t.Fatalf("expected 1 artifact, got %d", len(artifacts))
+	}
+	a := artifacts[0]
+	if a.ID != "5001" || a.Name != "test-report" || a.Size != 2048 {
+		t.Errorf("unexpected artifact: %+v", a)
+	}
+	if a.ExpiresAt.IsZero() {
+		t.Error("expected ExpiresAt to be parsed")
+	}
+}
+
+func TestListArtifacts_FollowsPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/waabox/gitdeck/actions/runs/1001/artifacts" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		id := float64(5001)
+		if r.URL.Query().Get("page") == "2" {
+			id = 5002
+		} else {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?per_page=100&page=2>; rel="next"`, r.Host, r.URL.Path))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total_count": float64(2),
+			"artifacts":   []map[string]interface{}{{"id": id, "name": fmt.Sprintf("report-%v", id)}},
+		})
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	artifacts, err := adapter.ListArtifacts(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "1001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(artifacts) != 2 || artifacts[1].ID != "5002" {
+		t.Errorf("expected the artifacts of both pages, got %+v", artifacts)
+	}
+}
+
+func TestDownloadArtifact_StreamsZipAndReportsProgress(t *testing.T) {
+	payload := strings.Repeat("x", 4096)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/waabox/gitdeck/actions/artifacts/5001/zip" {
+			w.Header().Set("Content-Type", "application/zip")
+			fmt.Fprint(w, payload)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	var buf bytes.Buffer
+	var lastWritten, lastTotal int64
+	err := adapter.DownloadArtifact(domain.Repository{Owner: "waabox", Name: "gitdeck"},
+		domain.Artifact{ID: "5001", Size: 4096}, &buf,
+		func(written, total int64) { lastWritten, lastTotal = written, total })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != payload {
+		t.Errorf("expected %d bytes written, got %d", len(payload), buf.Len())
+	}
+	if lastWritten != 4096 || lastTotal != 4096 {
+		t.Errorf("expected final progress 4096/4096, got %d/%d", lastWritten, lastTotal)
+	}
+}
+
+func TestGetTestReport_ParsesJUnitFilesFromArtifacts(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	report, _ := zw.Create("reports/junit.xml")
+	fmt.Fprint(report, `<testsuite name="api"><testcase name="TestCreate"><failure message="boom">trace</failure></testcase><testcase name="TestList"/></testsuite>`)
+	other, _ := zw.Create("pom.xml")
+	fmt.Fprint(other, `<project/>`)
+	zw.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/actions/runs/1001/artifacts":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"artifacts": []map[string]interface{}{
+					{"id": float64(5001), "name": "test-results", "size_in_bytes": float64(archive.Len())},
+					{"id": float64(5002), "name": "binary", "size_in_bytes": float64(1 << 30)},
+				},
+			})
+		case "/repos/waabox/gitdeck/actions/artifacts/5001/zip":
+			w.Write(archive.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	result, err := adapter.GetTestReport(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "1001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Cases) != 2 {
+		t.Fatalf("expected 2 test cases, got %d", len(result.Cases))
+	}
+	failed := result.Failed()
+	if len(failed) != 1 || failed[0].Name != "TestCreate" || failed[0].Message != "boom" {
+		t.Errorf("unexpected failed cases: %+v", failed)
+	}
+}
+
+func TestGetTestReport_SkipsUnrelatedAndUnreadableArtifacts(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	report, _ := zw.Create("junit.xml")
+	fmt.Fprint(report, `<testsuite name="api"><testcase name="TestList"/></testsuite>`)
+	zw.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/actions/runs/1001/artifacts":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"artifacts": []map[string]interface{}{
+					{"id": float64(5001), "name": "dist", "size_in_bytes": float64(1024)},
+					{"id": float64(5002), "name": "junit-unit", "size_in_bytes": float64(1024)},
+					{"id": float64(5003), "name": "test-results", "size_in_bytes": float64(archive.Len())},
+				},
+			})
+		case "/repos/waabox/gitdeck/actions/artifacts/5002/zip":
+			w.Write([]byte("not a zip archive"))
+		case "/repos/waabox/gitdeck/actions/artifacts/5003/zip":
+			w.Write(archive.Bytes())
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	result, err := adapter.GetTestReport(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "1001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Cases) != 1 || result.Cases[0].Name != "TestList" {
+		t.Errorf("expected the cases of the readable artifact, got %+v", result.Cases)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "junit-unit") {
+		t.Errorf("expected a warning about the unreadable artifact, got %v", result.Warnings)
+	}
+}
+
+func TestGetJobAnnotations_ReturnsCheckRunAnnotations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/waabox/gitdeck/check-runs/2001/annotations" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"path":             "internal/app.go",
+				"start_line":       float64(12),
+				"end_line":         float64(12),
+				"annotation_level": "failure",
+				"title":            "golangci-lint",
+				"message":          "ineffectual assignment to err",
+			}})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	annotations, err := adapter.GetJobAnnotations(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "2001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(annotations) != 1 {
+		t.Fatalf("expected 1 annotation, got %d", len(annotations))
+	}
+	a := annotations[0]
+	if a.Path != "internal/app.go" || a.StartLine != 12 || a.Level != domain.AnnotationFailure {
+		t.Errorf("unexpected annotation: %+v", a)
+	}
+}
+
+func TestListPipelines_AddsPullRequestDetails(t *testing.T) {
+	pullRequestCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/actions/runs":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"workflow_runs": []map[string]interface{}{{
+					"id":          float64(1001),
+					"head_branch": "feature/login",
+					"status":      "completed",
+					"conclusion":  "success",
+					"pull_requests": []map[string]interface{}{{
+						"number": float64(42),
+						"head":   map[string]interface{}{"ref": "feature/login"},
+						"base":   map[string]interface{}{"ref": "main"},
+					}},
+				}},
+			})
+		case "/repos/waabox/gitdeck/pulls/42":
+			pullRequestCalls++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"number": float64(42),
+				"title":  "Add login page",
+				"draft":  true,
+				"head":   map[string]interface{}{"ref": "feature/login"},
+				"base":   map[string]interface{}{"ref": "main"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	repo := domain.Repository{Owner: "waabox", Name: "gitdeck"}
+	for i := 0; i < 2; i++ {
+		pipelines, err := adapter.ListPipelines(repo)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mr := pipelines[0].MergeRequest
+		if mr == nil {
+			t.Fatal("expected pipeline to reference a pull request")
+		}
+		want := domain.MergeRequest{Kind: domain.KindPullRequest, Number: 42, Title: "Add login page", SourceBranch: "feature/login", TargetBranch: "main", Draft: true}
+		if *mr != want {
+			t.Errorf("expected %+v, got %+v", want, *mr)
+		}
+	}
+	if pullRequestCalls != 1 {
+		t.Errorf("expected pull request to be fetched once, got %d", pullRequestCalls)
+	}
+}
+
+func TestPullRequestDetails_RefreshedByDetailAndFailuresNotRetriedEachTime(t *testing.T) {
+	title, pullRequestCalls := "", 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		run := map[string]interface{}{
+			"id": float64(1001), "status": "completed", "conclusion": "success",
+			"pull_requests": []map[string]interface{}{{"number": float64(42)}},
+		}
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/actions/runs":
+			json.NewEncoder(w).Encode(map[string]interface{}{"workflow_runs": []map[string]interface{}{run}})
+		case "/repos/waabox/gitdeck/actions/runs/1001":
+			json.NewEncoder(w).Encode(run)
+		case "/repos/waabox/gitdeck/actions/runs/1001/jobs":
+			json.NewEncoder(w).Encode(map[string]interface{}{"jobs": []interface{}{}})
+		case "/repos/waabox/gitdeck/pulls/42":
+			pullRequestCalls++
+			if title == "" {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"number": float64(42), "title": title})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	repo := domain.Repository{Owner: "waabox", Name: "gitdeck"}
+	for i := 0; i < 2; i++ {
+		if _, err := adapter.ListPipelines(repo); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if pullRequestCalls != 1 {
+		t.Errorf("expected a failed lookup not to be retried right away, got %d calls", pullRequestCalls)
+	}
+
+	title = "Add login page"
+	pipeline, err := adapter.GetPipeline(repo, "1001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pipeline.MergeRequest.Title != "Add login page" {
+		t.Errorf("expected the detail to fetch the pull request again, got %+v", pipeline.MergeRequest)
+	}
+	title = "Add login and logout pages"
+	adapter.GetPipeline(repo, "1001")
+	pipelines, _ := adapter.ListPipelines(repo)
+	if pipelines[0].MergeRequest.Title != "Add login and logout pages" {
+		t.Errorf("expected the list to show the refreshed title, got %+v", pipelines[0].MergeRequest)
+	}
+}
+
+func TestListMergeRequestPipelines_KeepsRunsOfThePullRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/waabox/gitdeck/actions/runs" && r.URL.Query().Get("branch") == "feature/login" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"workflow_runs": []map[string]interface{}{
+					{"id": float64(1002), "pull_requests": []map[string]interface{}{{"number": float64(42)}}},
+					{"id": float64(1001), "pull_requests": []map[string]interface{}{}},
+				},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	pipelines, err := adapter.ListMergeRequestPipelines(
+		domain.Repository{Owner: "waabox", Name: "gitdeck"},
+		domain.MergeRequest{Number: 42, SourceBranch: "feature/login"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pipelines) != 1 || pipelines[0].ID != "1002" {
+		t.Errorf("expected only run 1002, got %+v", pipelines)
+	}
+}
+
+func TestListWorkflowPipelines_ReturnsRunsWithWorkflowDetails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/waabox/gitdeck/actions/workflows/161335/runs" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"workflow_runs": []map[string]interface{}{{
+					"id":          float64(1001),
+					"name":        "CI",
+					"workflow_id": float64(161335),
+					"event":       "pull_request",
+					"run_attempt": float64(2),
+					"status":      "completed",
+					"conclusion":  "success",
+				}},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	pipelines, err := adapter.ListWorkflowPipelines(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "161335")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pipelines) != 1 {
+		t.Fatalf("expected 1 pipeline, got %d", len(pipelines))
+	}
+	p := pipelines[0]
+	if p.Workflow != "CI" || p.WorkflowID != "161335" || p.Event != "pull_request" || p.Attempt != 2 {
+		t.Errorf("unexpected workflow details: %+v", p)
+	}
+}
+
+func TestGetPipelineAttempt_ReturnsAttemptWithItsJobs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/actions/runs/1001/attempts/1":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": float64(1001), "run_attempt": float64(1), "status": "completed", "conclusion": "failure",
+			})
+		case "/repos/waabox/gitdeck/actions/runs/1001/attempts/1/jobs":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"jobs": []map[string]interface{}{{
+					"id": float64(2001), "name": "test", "run_attempt": float64(1),
+					"status": "completed", "conclusion": "failure",
+				}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	pipeline, err := adapter.GetPipelineAttempt(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "1001", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pipeline.Attempt != 1 || pipeline.Status != domain.StatusFailed {
+		t.Errorf("unexpected attempt: %+v", pipeline)
+	}
+	if len(pipeline.Jobs) != 1 || pipeline.Jobs[0].ID != "2001" || pipeline.Jobs[0].Attempt != 1 {
+		t.Errorf("unexpected attempt jobs: %+v", pipeline.Jobs)
+	}
+}
+
+func TestListEnvironments_ReturnsLastDeploymentLinkedToRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/repos/waabox/gitdeck/environments":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"environments": []map[string]interface{}{{"name": "production"}, {"name": "preview"}},
+			})
+		case r.URL.Path == "/repos/waabox/gitdeck/deployments" && r.URL.Query().Get("environment") == "production":
+			json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"id": float64(77), "ref": "main", "sha": "abc1234",
+				"creator":    map[string]interface{}{"login": "waabox"},
+				"created_at": "2026-01-01T10:00:00Z",
+			}})
+		case r.URL.Path == "/repos/waabox/gitdeck/deployments":
+			json.NewEncoder(w).Encode([]map[string]interface{}{})
+		case r.URL.Path == "/repos/waabox/gitdeck/deployments/77/statuses":
+			json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"state":   "success",
+				"log_url": "https://github.com/waabox/gitdeck/actions/runs/1001/job/2001",
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	environments, err := adapter.ListEnvironments(domain.Repository{Owner: "waabox", Name: "gitdeck"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(environments) != 2 {
+		t.Fatalf("expected 2 environments, got %d", len(environments))
+	}
+	if environments[1].LastDeployment != nil {
+		t.Errorf("expected preview to have no deployment, got %+v", environments[1].LastDeployment)
+	}
+	d := environments[0].LastDeployment
+	if d == nil {
+		t.Fatal("expected a deployment to production")
+	}
+	if d.Ref != "main" || d.Author != "waabox" || d.Status != domain.StatusSuccess || d.PipelineID != "1001" {
+		t.Errorf("unexpected deployment: %+v", d)
+	}
+}
+
+func TestListSchedules_ParsesWorkflowCronAndLastScheduledRun(t *testing.T) {
+	workflow := "on:\n  schedule:\n    - cron: '0 3 * * *'\n  push:\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/actions/workflows":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"workflows": []map[string]interface{}{
+					{"id": float64(7), "name": "Nightly", "path": ".github/workflows/nightly.yml", "state": "active"},
+					{"id": float64(8), "name": "CI", "path": ".github/workflows/ci.yml", "state": "active"},
+					{"id": float64(9), "name": "Dependabot", "path": "dynamic/dependabot/dependabot-updates", "state": "active"},
+				},
+			})
+		case "/repos/waabox/gitdeck/contents/.github/workflows/nightly.yml":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"encoding": "base64", "content": base64.StdEncoding.EncodeToString([]byte(workflow)),
+			})
+		case "/repos/waabox/gitdeck/contents/.github/workflows/ci.yml":
+			json.NewEncoder(w).Encode(map[string]interface{}{"encoding": "base64", "content": ""})
+		case "/repos/waabox/gitdeck/actions/workflows/7/runs":
+			if r.URL.Query().Get("event") != "schedule" {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"workflow_runs": []map[string]interface{}{{
+					"id": float64(1001), "head_branch": "main", "status": "completed", "conclusion": "failure",
+				}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	schedules, err := adapter.ListSchedules(domain.Repository{Owner: "waabox", Name: "gitdeck"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schedules) != 1 {
+		t.Fatalf("expected only the scheduled workflow, got %+v", schedules)
+	}
+	s := schedules[0]
+	if s.Description != "Nightly" || s.Cron != "0 3 * * *" || !s.Active {
+		t.Errorf("unexpected schedule: %+v", s)
+	}
+	if s.NextRunAt.IsZero() || s.NextRunAt.Hour() != 3 || s.NextRunAt.Minute() != 0 {
+		t.Errorf("expected next run at 03:00 UTC, got %v", s.NextRunAt)
+	}
+	if s.Ref != "main" || s.LastStatus != domain.StatusFailed || s.LastPipelineID != "1001" {
+		t.Errorf("unexpected last run: %+v", s)
+	}
+}
+
+func TestGetPipeline_ParsesJobRunner(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/actions/runs/1001":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": float64(1001), "status": "in_progress"})
+		case "/repos/waabox/gitdeck/actions/runs/1001/jobs":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"jobs": []map[string]interface{}{{
+					"id": float64(2001), "name": "build", "status": "in_progress",
+					"runner_name": "GitHub Actions 12", "labels": []string{"ubuntu-latest"},
+				}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	pipeline, err := adapter.GetPipeline(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "1001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job := pipeline.Jobs[0]
+	if job.Runner != "GitHub Actions 12" || len(job.RunnerLabels) != 1 || job.RunnerLabels[0] != "ubuntu-latest" {
+		t.Errorf("unexpected runner: %q %v", job.Runner, job.RunnerLabels)
+	}
+}
+
+func TestListRunners_ReturnsSelfHostedRunners(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/repos/waabox/gitdeck/actions/runners" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"runners": []map[string]interface{}{{
+				"id": float64(3), "name": "build-box", "status": "online", "busy": true,
+				"labels": []map[string]interface{}{{"name": "self-hosted"}, {"name": "linux"}},
+			}},
+		})
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	runners, err := adapter.ListRunners(domain.Repository{Owner: "waabox", Name: "gitdeck"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runners) != 1 {
+		t.Fatalf("expected 1 runner, got %d", len(runners))
+	}
+	r := runners[0]
+	if r.Name != "build-box" || !r.Online || !r.Busy || len(r.Labels) != 2 || r.Labels[1] != "linux" {
+		t.Errorf("unexpected runner: %+v", r)
+	}
+}
+
+func TestListSchedules_SkipsWorkflowsThatCannotBeRead(t *testing.T) {
+	workflow := base64.StdEncoding.EncodeToString([]byte("on:\n  schedule:\n    - cron: '0 3 * * *'\n"))
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/actions/workflows":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"workflows": []map[string]interface{}{
+					{"id": float64(6), "name": "Old", "path": ".github/workflows/old.yml", "state": "deleted"},
+					{"id": float64(7), "name": "Renamed", "path": ".github/workflows/renamed.yml", "state": "active"},
+					{"id": float64(8), "name": "Nightly", "path": ".github/workflows/nightly.yml", "state": "active"},
+				},
+			})
+		case "/repos/waabox/gitdeck/contents/.github/workflows/nightly.yml":
+			json.NewEncoder(w).Encode(map[string]interface{}{"encoding": "base64", "content": workflow})
+		case "/repos/waabox/gitdeck/actions/workflows/8/runs":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	schedules, err := adapter.ListSchedules(domain.Repository{Owner: "waabox", Name: "gitdeck"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schedules) != 1 || schedules[0].Description != "Nightly" || schedules[0].LastPipelineID != "" {
+		t.Errorf("expected the readable schedule without its last run, got %+v", schedules)
+	}
+	for _, path := range requested {
+		if strings.Contains(path, "old.yml") {
+			t.Errorf("expected the deleted workflow's file not to be fetched")
+		}
+	}
+}
+
+func TestListSchedules_FailsOnExpiredToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/repos/waabox/gitdeck/actions/workflows" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"workflows": []map[string]interface{}{
+					{"id": float64(8), "name": "Nightly", "path": ".github/workflows/nightly.yml", "state": "active"},
+				},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	if _, err := adapter.ListSchedules(domain.Repository{Owner: "waabox", Name: "gitdeck"}); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized so the token can be refreshed, got %v", err)
+	}
+}
+
+func TestListCommitChecks_AggregatesCheckRunsSuitesAndStatuses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/waabox/gitdeck/commits/abc1234/check-runs":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"check_runs": []map[string]interface{}{{
+					"name": "build", "status": "completed", "conclusion": "success",
+					"html_url": "https://github.com/waabox/gitdeck/runs/1",
+					"app":      map[string]interface{}{"name": "GitHub Actions"},
+				}},
+			})
+		case "/repos/waabox/gitdeck/commits/abc1234/check-suites":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"check_suites": []map[string]interface{}{
+					{"status": "completed", "conclusion": "success", "latest_check_runs_count": float64(1),
+						"app": map[string]interface{}{"name": "GitHub Actions"}},
+					{"status": "queued", "latest_check_runs_count": float64(0),
+						"app": map[string]interface{}{"name": "Dependabot"}},
+				},
+			})
+		case "/repos/waabox/gitdeck/commits/abc1234/status":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"statuses": []map[string]interface{}{{
+					"context": "ci/jenkins", "state": "failure", "description": "Build #12 failed",
+					"target_url": "https://jenkins.example.com/job/12",
+				}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := githubprovider.NewAdapter("test-token", srv.URL, 3)
+	checks, err := adapter.ListCommitChecks(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "abc1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(checks) != 3 {
+		t.Fatalf("expected suite, run and status without the empty suite, got %+v", checks)
+	}
+	if c := checks[0]; c.Kind != domain.CheckSuite || c.Name != "GitHub Actions" || c.Status != domain.StatusSuccess {
+		t.Errorf("unexpected suite: %+v", c)
+	}
+	if c := checks[1]; c.Kind != domain.CheckRun || c.Name != "build" || c.Source != "GitHub Actions" {
+		t.Errorf("unexpected check run: %+v", c)
+	}
+	if c := checks[2]; c.Kind != domain.CommitStatus || c.Name != "ci/jenkins" || c.Status != domain.StatusFailed {
+		t.Errorf("unexpected status: %+v", c)
+	}
+}
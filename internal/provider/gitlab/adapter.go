@@ -1,31 +1,65 @@
 package gitlab
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/waabox/gitdeck/internal/apiclient"
 	"github.com/waabox/gitdeck/internal/domain"
 )
 
 const defaultBaseURL = "https://gitlab.com"
 
+// failedLookupTTL is how long a failed lookup of merge request, commit or
+// masked variable details is remembered, so that it is retried on a later
+// refresh rather than on each one.
+const failedLookupTTL = time.Minute
+
+// codeQualityReportPath is the default file name of the artifacts:reports:codequality report.
+const codeQualityReportPath = "gl-code-quality-report.json"
+
 // Adapter implements domain.PipelineProvider for GitLab CI.
 type Adapter struct {
 	mu      sync.Mutex
-	token   string
+	api     *apiclient.Client
 	baseURL string
 	limit   int
-	client  *http.Client
+	// mergeRequests caches merge request details by "project!iid".
+	mergeRequests map[string]domain.MergeRequest
+	// commits caches commit details by "project@sha".
+	commits map[string]gitLabCommit
+	// failedLookups holds when fetching a merge request or commit last
+	// failed, by the key of its cache.
+	failedLookups map[string]time.Time
+	// maskedKeys caches the keys of the masked CI/CD variables visible to
+	// each project, or why they are unknown, by project path.
+	maskedKeys map[string]maskedKeyLookup
 }
 
-// Ensure Adapter fully implements domain.PipelineProvider.
-var _ domain.PipelineProvider = (*Adapter)(nil)
+// Ensure Adapter fully implements domain.PipelineProvider and its optional capabilities.
+var (
+	_ domain.PipelineProvider             = (*Adapter)(nil)
+	_ domain.ArtifactProvider             = (*Adapter)(nil)
+	_ domain.TestReportProvider           = (*Adapter)(nil)
+	_ domain.VariableProvider             = (*Adapter)(nil)
+	_ domain.AnnotationProvider           = (*Adapter)(nil)
+	_ domain.MergeRequestPipelineProvider = (*Adapter)(nil)
+	_ domain.EnvironmentProvider          = (*Adapter)(nil)
+	_ domain.RunnerProvider               = (*Adapter)(nil)
+	_ domain.CommitCheckProvider          = (*Adapter)(nil)
+	_ domain.RateLimitReporter            = (*Adapter)(nil)
+	_ domain.ScheduleProvider             = (*Adapter)(nil)
+	_ domain.ScheduleController           = (*Adapter)(nil)
+)
 
 // NewAdapter creates a GitLab CI adapter.
 // baseURL can be a self-hosted GitLab instance URL; pass empty string for gitlab.com.
@@ -35,18 +69,37 @@ func NewAdapter(token string, baseURL string, limit int) *Adapter {
 		baseURL = defaultBaseURL
 	}
 	return &Adapter{
-		token:   token,
+		api:     apiclient.New("gitlab", token, nil),
 		baseURL: baseURL,
 		limit:   limit,
-		client:  &http.Client{Timeout: 15 * time.Second},
+
+		mergeRequests: make(map[string]domain.MergeRequest),
+		commits:       make(map[string]gitLabCommit),
+		failedLookups: make(map[string]time.Time),
+		maskedKeys:    make(map[string]maskedKeyLookup),
 	}
 }
 
 // SetToken updates the access token used for API requests.
 func (a *Adapter) SetToken(token string) {
-	a.mu.Lock()
-	defer a.mu.Unlock()
-	a.token = token
+	a.api.SetToken(token)
+}
+
+// RateLimit returns the API request quota as of the last response.
+func (a *Adapter) RateLimit() (domain.RateLimit, bool) {
+	return a.api.RateLimit()
+}
+
+// SetHTTPClient replaces the HTTP client used for API requests, e.g. with one
+// configured for a proxy or a custom CA. It must be called before the adapter is used.
+func (a *Adapter) SetHTTPClient(client *http.Client) {
+	a.api.SetHTTPClient(client)
+}
+
+// SetCache replaces the in-memory response cache, e.g. with an on-disk one.
+// It must be called before the adapter is used.
+func (a *Adapter) SetCache(cache apiclient.Cache) {
+	a.api.SetCache(cache)
 }
 
 // ListPipelines returns the most recent pipelines for the repository.
@@ -54,124 +107,309 @@ func (a *Adapter) ListPipelines(repo domain.Repository) ([]domain.Pipeline, erro
 	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines?per_page=%d", a.baseURL, projectID, a.limit)
 	var runs []gitLabPipeline
-	if err := a.get(apiURL, &runs); err != nil {
+	if err := a.api.Get(apiURL, &runs); err != nil {
+		return nil, err
+	}
+	pipelines := make([]domain.Pipeline, len(runs))
+	for i, r := range runs {
+		pipelines[i] = r.toPipeline()
+	}
+	a.addMergeRequestDetails(projectID, pipelines, false)
+	a.addCommitDetails(projectID, pipelines)
+	return pipelines, nil
+}
+
+// ListMergeRequestPipelines returns the most recent pipelines of a merge request.
+func (a *Adapter) ListMergeRequestPipelines(repo domain.Repository, mr domain.MergeRequest) ([]domain.Pipeline, error) {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d/pipelines?per_page=%d",
+		a.baseURL, projectID, mr.Number, a.limit)
+	var runs []gitLabPipeline
+	if err := a.api.Get(apiURL, &runs); err != nil {
 		return nil, err
 	}
 	pipelines := make([]domain.Pipeline, len(runs))
 	for i, r := range runs {
 		pipelines[i] = r.toPipeline()
 	}
+	a.addMergeRequestDetails(projectID, pipelines, false)
+	a.addCommitDetails(projectID, pipelines)
 	return pipelines, nil
 }
 
+// addCommitDetails fills in the commit message and commit author of pipelines.
+// The commit author is not the triggering user, which the pipeline list does
+// not include. Commits never change, so each one is fetched once and cached
+// for the lifetime of the adapter. Lookup failures are ignored, and retried
+// after failedLookupTTL.
+func (a *Adapter) addCommitDetails(projectID string, pipelines []domain.Pipeline) {
+	for i := range pipelines {
+		sha := pipelines[i].CommitSHA
+		if sha == "" {
+			continue
+		}
+		key := projectID + "@" + sha
+		a.mu.Lock()
+		commit, ok := a.commits[key]
+		failed := time.Since(a.failedLookups[key]) < failedLookupTTL
+		a.mu.Unlock()
+		if !ok {
+			if failed {
+				continue
+			}
+			apiURL := fmt.Sprintf("%s/api/v4/projects/%s/repository/commits/%s", a.baseURL, projectID, sha)
+			err := a.api.Get(apiURL, &commit)
+			a.mu.Lock()
+			if err != nil {
+				a.failedLookups[key] = time.Now()
+			} else {
+				a.commits[key] = commit
+				delete(a.failedLookups, key)
+			}
+			a.mu.Unlock()
+			if err != nil {
+				continue
+			}
+		}
+		pipelines[i].CommitMsg = commit.Title
+		pipelines[i].CommitAuthor = commit.AuthorName
+	}
+}
+
+// addMergeRequestDetails fills in the merge request of pipelines that ran for one.
+// Pipelines only reference the merge request through their ref, so each one is
+// fetched once and cached; refresh fetches them again, so that opening a
+// pipeline picks up a new title or draft state. Lookup failures are ignored,
+// and retried after failedLookupTTL: the pipeline keeps the merge request
+// number parsed from its ref.
+func (a *Adapter) addMergeRequestDetails(projectID string, pipelines []domain.Pipeline, refresh bool) {
+	for i := range pipelines {
+		if pipelines[i].MergeRequest == nil {
+			continue
+		}
+		iid := pipelines[i].MergeRequest.Number
+		key := fmt.Sprintf("%s!%d", projectID, iid)
+		a.mu.Lock()
+		mr, ok := a.mergeRequests[key]
+		failed := time.Since(a.failedLookups[key]) < failedLookupTTL
+		a.mu.Unlock()
+		if refresh || (!ok && !failed) {
+			apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d", a.baseURL, projectID, iid)
+			var raw gitLabMergeRequest
+			err := a.api.Get(apiURL, &raw)
+			a.mu.Lock()
+			if err != nil {
+				a.failedLookups[key] = time.Now()
+			} else {
+				mr, ok = raw.toMergeRequest(), true
+				a.mergeRequests[key] = mr
+				delete(a.failedLookups, key)
+			}
+			a.mu.Unlock()
+		}
+		if !ok {
+			continue
+		}
+		pipelines[i].MergeRequest = &mr
+		// Show the branch under review instead of the synthetic merge request ref.
+		pipelines[i].Branch = mr.SourceBranch
+	}
+}
+
 // GetPipeline returns a single pipeline with all its jobs.
 func (a *Adapter) GetPipeline(repo domain.Repository, id domain.PipelineID) (domain.Pipeline, error) {
 	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
 
 	pipelineURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s", a.baseURL, projectID, id)
 	var run gitLabPipeline
-	if err := a.get(pipelineURL, &run); err != nil {
+	if err := a.api.Get(pipelineURL, &run); err != nil {
 		return domain.Pipeline{}, err
 	}
 
-	jobsURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/jobs", a.baseURL, projectID, id)
-	var rawJobs []gitLabJob
-	if err := a.get(jobsURL, &rawJobs); err != nil {
+	// Retried jobs are included so that every attempt of a job can be inspected.
+	jobsURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/jobs?include_retried=true&per_page=100", a.baseURL, projectID, id)
+	rawJobs, err := apiclient.GetAll[gitLabJob](a.api, jobsURL)
+	if err != nil {
 		return domain.Pipeline{}, err
 	}
 
-	pipeline := run.toPipeline()
-	pipeline.Jobs = make([]domain.Job, len(rawJobs))
-	for i, j := range rawJobs {
-		pipeline.Jobs[i] = j.toJob()
+	// Trigger jobs are not part of the jobs list. Instances without the
+	// bridges endpoint simply have no downstream pipelines to show.
+	bridgesURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/bridges?per_page=100", a.baseURL, projectID, id)
+	rawBridges, err := apiclient.GetAll[gitLabBridge](a.api, bridgesURL)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return domain.Pipeline{}, err
 	}
-	return pipeline, nil
-}
 
-func (a *Adapter) get(apiURL string, target interface{}) error {
-	a.mu.Lock()
-	token := a.token
-	a.mu.Unlock()
-
-	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
-	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+	enriched := []domain.Pipeline{run.toPipeline()}
+	a.addMergeRequestDetails(projectID, enriched, true)
+	a.addCommitDetails(projectID, enriched)
+	pipeline := enriched[0]
+	pipeline.Jobs = make([]domain.Job, 0, len(rawJobs)+len(rawBridges))
+	for _, j := range rawJobs {
+		pipeline.Jobs = append(pipeline.Jobs, j.toJob())
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-
-	resp, err := a.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+	numberAttempts(pipeline.Jobs)
+	for _, b := range rawBridges {
+		job := b.toJob()
+		if b.DownstreamPipeline != nil {
+			job.Downstream = &domain.DownstreamPipeline{
+				Repository: a.downstreamRepository(repo, b.DownstreamPipeline.WebURL),
+				Pipeline:   b.DownstreamPipeline.toPipeline(),
+			}
+		}
+		pipeline.Jobs = append(pipeline.Jobs, job)
 	}
-	defer resp.Body.Close()
+	return pipeline, nil
+}
 
-	if resp.StatusCode == http.StatusUnauthorized {
-		return fmt.Errorf("gitlab API error: %s: %w", resp.Status, domain.ErrUnauthorized)
+// GetPipelineVariables returns the variables the pipeline was triggered with.
+// Values of variables that are masked, either on the pipeline variable itself
+// or as a project, group or instance CI/CD variable of the same key, are
+// dropped. When the masked keys cannot be told, every value is dropped.
+func (a *Adapter) GetPipelineVariables(repo domain.Repository, id domain.PipelineID) (domain.PipelineVariables, error) {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/variables", a.baseURL, projectID, id)
+	var raw []gitLabVariable
+	if _, err := a.api.GetIfExists(apiURL, &raw); err != nil || len(raw) == 0 {
+		return domain.PipelineVariables{}, err
 	}
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("gitlab API error: %s", resp.Status)
+	masked, unknown := a.maskedVariableKeys(repo)
+	result := domain.PipelineVariables{Variables: make([]domain.Variable, len(raw)), ValuesHidden: unknown}
+	for i, v := range raw {
+		result.Variables[i] = domain.Variable{Key: v.Key, Value: v.Value}
+		if unknown != "" || v.Masked || v.Hidden || masked[v.Key] {
+			result.Variables[i] = domain.Variable{Key: v.Key, Masked: true}
+		}
 	}
-	return json.NewDecoder(resp.Body).Decode(target)
+	return result, nil
+}
+
+// maskedKeyLookup is the outcome of reading the keys of the masked CI/CD
+// variables visible to a project: the keys, or why they are unknown.
+type maskedKeyLookup struct {
+	keys    map[string]bool
+	unknown string
+	at      time.Time
 }
 
-// getText fetches a URL and returns the response body as a plain string.
-func (a *Adapter) getText(apiURL string) (string, error) {
+// maskedVariableKeys returns the keys of the masked CI/CD variables defined
+// for the project, its ancestor groups and the instance, or why they are
+// unknown. A complete set is cached for the lifetime of the adapter, and an
+// unknown one for failedLookupTTL.
+func (a *Adapter) maskedVariableKeys(repo domain.Repository) (map[string]bool, string) {
+	fullPath := repo.Owner + "/" + repo.Name
 	a.mu.Lock()
-	token := a.token
+	lookup, ok := a.maskedKeys[fullPath]
 	a.mu.Unlock()
-
-	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
-	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
-	}
-	req.Header.Set("Authorization", "Bearer "+token)
-
-	resp, err := a.client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("executing request: %w", err)
+	if !ok || (lookup.unknown != "" && time.Since(lookup.at) >= failedLookupTTL) {
+		lookup = a.readMaskedKeys(fullPath)
+		lookup.at = time.Now()
+		a.mu.Lock()
+		a.maskedKeys[fullPath] = lookup
+		a.mu.Unlock()
 	}
-	defer resp.Body.Close()
+	return lookup.keys, lookup.unknown
+}
 
-	if resp.StatusCode == http.StatusUnauthorized {
-		return "", fmt.Errorf("gitlab API error: %s: %w", resp.Status, domain.ErrUnauthorized)
+// readMaskedKeys reads the keys of the masked CI/CD variables of the project,
+// its ancestor groups and the instance. Reading the project's needs the
+// Maintainer role; without it, or after any other failure, the keys are
+// unknown. Group and instance variables the user is not allowed to read
+// cannot be masked variables this user could see, so those levels count as
+// having none. A full page may not hold every variable, so it leaves the
+// keys unknown too.
+func (a *Adapter) readMaskedKeys(fullPath string) maskedKeyLookup {
+	type level struct {
+		name     string
+		url      string
+		optional bool
 	}
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("gitlab API error: %s", resp.Status)
+	levels := []level{{
+		name: "the project",
+		url:  fmt.Sprintf("%s/api/v4/projects/%s/variables?per_page=100", a.baseURL, url.PathEscape(fullPath)),
+	}}
+	segments := strings.Split(fullPath, "/")
+	for i := len(segments) - 1; i > 0; i-- {
+		group := strings.Join(segments[:i], "/")
+		levels = append(levels, level{
+			name:     "group " + group,
+			url:      fmt.Sprintf("%s/api/v4/groups/%s/variables?per_page=100", a.baseURL, url.PathEscape(group)),
+			optional: true,
+		})
 	}
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("reading log response: %w", err)
+	levels = append(levels, level{
+		name:     "the instance",
+		url:      fmt.Sprintf("%s/api/v4/admin/ci/variables?per_page=100", a.baseURL),
+		optional: true,
+	})
+
+	keys := make(map[string]bool)
+	for _, l := range levels {
+		var raw []gitLabVariable
+		err := a.api.Get(l.url, &raw)
+		switch {
+		case err != nil && l.optional && (errors.Is(err, domain.ErrForbidden) || errors.Is(err, domain.ErrNotFound)):
+			continue
+		case errors.Is(err, domain.ErrForbidden):
+			return maskedKeyLookup{unknown: "telling which variables are masked needs the Maintainer role on the project"}
+		case err != nil:
+			return maskedKeyLookup{unknown: fmt.Sprintf("the CI/CD variables of %s could not be read to tell which are masked: %v", l.name, err)}
+		case len(raw) >= 100:
+			return maskedKeyLookup{unknown: fmt.Sprintf("%s has too many CI/CD variables to tell which are masked", l.name)}
+		}
+		for _, v := range raw {
+			if v.Masked || v.Hidden {
+				keys[v.Key] = true
+			}
+		}
 	}
-	return string(b), nil
+	return maskedKeyLookup{keys: keys}
 }
 
-// post sends a POST request with no body and discards the response body.
-// GitLab mutation endpoints (retry, cancel) return 200 or 201 with a JSON body
-// that we do not need.
-func (a *Adapter) post(apiURL string) error {
-	a.mu.Lock()
-	token := a.token
-	a.mu.Unlock()
-
-	req, err := http.NewRequest(http.MethodPost, apiURL, nil)
-	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+// numberAttempts sets the attempt number of each job. GitLab retries a job
+// by creating a new one with the same name and a higher ID.
+func numberAttempts(jobs []domain.Job) {
+	byName := make(map[string][]*domain.Job)
+	for i := range jobs {
+		byName[jobs[i].Name] = append(byName[jobs[i].Name], &jobs[i])
+	}
+	for _, tries := range byName {
+		sort.Slice(tries, func(a, b int) bool {
+			idA, _ := strconv.ParseInt(tries[a].ID, 10, 64)
+			idB, _ := strconv.ParseInt(tries[b].ID, 10, 64)
+			return idA < idB
+		})
+		for i, job := range tries {
+			job.Attempt = i + 1
+		}
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
+}
 
-	resp, err := a.client.Do(req)
+// downstreamRepository resolves the project of a downstream pipeline from its
+// web URL, e.g. https://gitlab.com/group/sub/project/-/pipelines/42.
+// Child pipelines run in the upstream project, which is returned when the URL
+// cannot be parsed.
+func (a *Adapter) downstreamRepository(upstream domain.Repository, webURL string) domain.Repository {
+	u, err := url.Parse(webURL)
 	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+		return upstream
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusUnauthorized {
-		return fmt.Errorf("gitlab API error: %s: %w", resp.Status, domain.ErrUnauthorized)
+	path := u.Path
+	if base, err := url.Parse(a.baseURL); err == nil {
+		// Self-hosted instances may be served under a path prefix.
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	project, _, found := strings.Cut(strings.Trim(path, "/"), "/-/")
+	slash := strings.LastIndex(project, "/")
+	if !found || slash < 0 {
+		return upstream
 	}
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("gitlab API error: %s", resp.Status)
+	repo := domain.Repository{Owner: project[:slash], Name: project[slash+1:]}
+	if repo == (domain.Repository{Owner: upstream.Owner, Name: upstream.Name}) {
+		return upstream
 	}
-	return nil
+	return repo
 }
 
 // GetJobLogs returns the full raw log trace for the given job.
@@ -179,7 +417,7 @@ func (a *Adapter) GetJobLogs(repo domain.Repository, jobID domain.JobID) (string
 	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/jobs/%s/trace",
 		a.baseURL, projectID, jobID)
-	return a.getText(apiURL)
+	return a.api.GetText(apiURL)
 }
 
 // RerunPipeline retries a failed or cancelled pipeline.
@@ -187,7 +425,7 @@ func (a *Adapter) RerunPipeline(repo domain.Repository, id domain.PipelineID) er
 	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/retry",
 		a.baseURL, projectID, id)
-	return a.post(apiURL)
+	return a.api.Post(apiURL)
 }
 
 // CancelPipeline cancels a running pipeline.
@@ -195,7 +433,342 @@ func (a *Adapter) CancelPipeline(repo domain.Repository, id domain.PipelineID) e
 	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/cancel",
 		a.baseURL, projectID, id)
-	return a.post(apiURL)
+	return a.api.Post(apiURL)
+}
+
+// ListArtifacts returns the artifact archives of the jobs in the given pipeline.
+// GitLab stores one archive per job, so each artifact is named after its job.
+func (a *Adapter) ListArtifacts(repo domain.Repository, id domain.PipelineID) ([]domain.Artifact, error) {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/jobs?per_page=100", a.baseURL, projectID, id)
+	rawJobs, err := apiclient.GetAll[gitLabJob](a.api, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	var artifacts []domain.Artifact
+	for _, j := range rawJobs {
+		if j.ArtifactsFile == nil {
+			continue
+		}
+		expires, _ := time.Parse(time.RFC3339, j.ArtifactsExpireAt)
+		jobID := strconv.FormatInt(j.ID, 10)
+		artifacts = append(artifacts, domain.Artifact{
+			ID:        jobID,
+			Name:      j.Name,
+			JobID:     jobID,
+			Size:      j.ArtifactsFile.Size,
+			ExpiresAt: expires,
+			Expired:   !expires.IsZero() && expires.Before(time.Now()),
+		})
+	}
+	return artifacts, nil
+}
+
+// DownloadArtifact streams the artifact archive of the artifact's job to dst.
+func (a *Adapter) DownloadArtifact(repo domain.Repository, artifact domain.Artifact, dst io.Writer, progress func(written, total int64)) error {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/jobs/%s/artifacts",
+		a.baseURL, projectID, artifact.JobID)
+	return a.api.Download(apiURL, dst, artifact.Size, progress)
+}
+
+// GetTestReport returns the pipeline's test report as parsed by GitLab from
+// the jobs' artifacts:reports:junit files.
+func (a *Adapter) GetTestReport(repo domain.Repository, id domain.PipelineID) (domain.TestReport, error) {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/test_report", a.baseURL, projectID, id)
+	var raw gitLabTestReport
+	if err := a.api.Get(apiURL, &raw); err != nil {
+		return domain.TestReport{}, err
+	}
+	return raw.toTestReport(), nil
+}
+
+// GetJobAnnotations returns the Code Quality findings reported by the given job.
+// Jobs without a code quality report return no annotations.
+func (a *Adapter) GetJobAnnotations(repo domain.Repository, jobID domain.JobID) ([]domain.Annotation, error) {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/jobs/%s/artifacts/%s",
+		a.baseURL, projectID, jobID, codeQualityReportPath)
+	var findings []codeQualityFinding
+	found, err := a.api.GetIfExists(apiURL, &findings)
+	if err != nil || !found {
+		return nil, err
+	}
+	annotations := make([]domain.Annotation, len(findings))
+	for i, f := range findings {
+		annotations[i] = f.toAnnotation()
+	}
+	return annotations, nil
+}
+
+// codeQualityFinding is an entry of a GitLab Code Quality (Code Climate) report.
+type codeQualityFinding struct {
+	Description string `json:"description"`
+	CheckName   string `json:"check_name"`
+	Severity    string `json:"severity"`
+	Location    struct {
+		Path  string `json:"path"`
+		Lines struct {
+			Begin int `json:"begin"`
+			End   int `json:"end"`
+		} `json:"lines"`
+	} `json:"location"`
+}
+
+func (f codeQualityFinding) toAnnotation() domain.Annotation {
+	level := domain.AnnotationNotice
+	switch f.Severity {
+	case "blocker", "critical", "major":
+		level = domain.AnnotationFailure
+	case "minor":
+		level = domain.AnnotationWarning
+	}
+	end := f.Location.Lines.End
+	if end == 0 {
+		end = f.Location.Lines.Begin
+	}
+	return domain.Annotation{
+		Path:      f.Location.Path,
+		StartLine: f.Location.Lines.Begin,
+		EndLine:   end,
+		Level:     level,
+		Title:     f.CheckName,
+		Message:   f.Description,
+	}
+}
+
+type gitLabTestReport struct {
+	TestSuites []struct {
+		Name      string `json:"name"`
+		TestCases []struct {
+			Status        string  `json:"status"`
+			Name          string  `json:"name"`
+			ClassName     string  `json:"classname"`
+			ExecutionTime float64 `json:"execution_time"`
+			SystemOutput  string  `json:"system_output"`
+			StackTrace    string  `json:"stack_trace"`
+		} `json:"test_cases"`
+	} `json:"test_suites"`
+}
+
+func (r gitLabTestReport) toTestReport() domain.TestReport {
+	var report domain.TestReport
+	for _, suite := range r.TestSuites {
+		for _, c := range suite.TestCases {
+			message, _, _ := strings.Cut(strings.TrimSpace(c.SystemOutput), "\n")
+			stackTrace := c.StackTrace
+			if stackTrace == "" {
+				stackTrace = c.SystemOutput
+			}
+			report.Cases = append(report.Cases, domain.TestCase{
+				Suite:      suite.Name,
+				ClassName:  c.ClassName,
+				Name:       c.Name,
+				Status:     mapGitLabTestStatus(c.Status),
+				Duration:   time.Duration(c.ExecutionTime * float64(time.Second)),
+				Message:    message,
+				StackTrace: stackTrace,
+			})
+		}
+	}
+	return report
+}
+
+func mapGitLabTestStatus(status string) domain.TestStatus {
+	switch status {
+	case "failed":
+		return domain.TestFailed
+	case "error":
+		return domain.TestErrored
+	case "skipped":
+		return domain.TestSkipped
+	default:
+		return domain.TestPassed
+	}
+}
+
+// ListCommitChecks returns the latest commit status of each name reported
+// for the commit. These include the jobs of GitLab's own pipelines as well as
+// statuses posted by external CI systems through the commit status API.
+func (a *Adapter) ListCommitChecks(repo domain.Repository, sha string) ([]domain.CommitCheck, error) {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/repository/commits/%s/statuses?per_page=100", a.baseURL, projectID, sha)
+	var raw []struct {
+		Name        string `json:"name"`
+		Status      string `json:"status"`
+		Description string `json:"description"`
+		TargetURL   string `json:"target_url"`
+		Author      struct {
+			Username string `json:"username"`
+		} `json:"author"`
+	}
+	if err := a.api.Get(apiURL, &raw); err != nil {
+		return nil, err
+	}
+	checks := make([]domain.CommitCheck, len(raw))
+	for i, s := range raw {
+		checks[i] = domain.CommitCheck{
+			Name:        s.Name,
+			Kind:        domain.CommitStatus,
+			Source:      s.Author.Username,
+			Status:      mapGitLabStatus(s.Status),
+			Description: s.Description,
+			URL:         s.TargetURL,
+		}
+	}
+	return checks, nil
+}
+
+// ListRunners returns the runners available to the project: project runners
+// plus the group and instance runners it inherits. The list endpoint does not
+// include tags, so each runner's details are fetched as well; runners whose
+// details are not visible to the user are listed without tags.
+func (a *Adapter) ListRunners(repo domain.Repository) ([]domain.Runner, error) {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/runners?per_page=100", a.baseURL, projectID)
+	var rawRunners []gitLabRunner
+	if err := a.api.Get(apiURL, &rawRunners); err != nil {
+		return nil, err
+	}
+	runners := make([]domain.Runner, len(rawRunners))
+	for i, r := range rawRunners {
+		detailURL := fmt.Sprintf("%s/api/v4/runners/%d", a.baseURL, r.ID)
+		detail := r
+		if err := a.api.Get(detailURL, &detail); err != nil {
+			detail = r
+		}
+		runners[i] = detail.toRunner()
+	}
+	return runners, nil
+}
+
+// ListEnvironments returns the project's available environments with their last deployment.
+// The list endpoint does not include deployments, so each environment is fetched individually;
+// an environment whose details cannot be read is listed without its last deployment.
+func (a *Adapter) ListEnvironments(repo domain.Repository) ([]domain.Environment, error) {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/environments?states=available&per_page=100", a.baseURL, projectID)
+	rawEnvironments, err := apiclient.GetAll[gitLabEnvironment](a.api, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	environments := make([]domain.Environment, len(rawEnvironments))
+	for i, env := range rawEnvironments {
+		envURL := fmt.Sprintf("%s/api/v4/projects/%s/environments/%d", a.baseURL, projectID, env.ID)
+		detail := env
+		if err := a.api.Get(envURL, &detail); err != nil {
+			detail = env
+		}
+		environments[i] = detail.toEnvironment()
+	}
+	return environments, nil
+}
+
+// ListSchedules returns the project's pipeline schedules. The list endpoint
+// does not include the last pipeline, so each schedule is fetched individually;
+// a schedule whose details cannot be read is listed without its last pipeline.
+func (a *Adapter) ListSchedules(repo domain.Repository) ([]domain.Schedule, error) {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipeline_schedules?per_page=100", a.baseURL, projectID)
+	rawSchedules, err := apiclient.GetAll[gitLabSchedule](a.api, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]domain.Schedule, len(rawSchedules))
+	for i, sched := range rawSchedules {
+		scheduleURL := fmt.Sprintf("%s/api/v4/projects/%s/pipeline_schedules/%d", a.baseURL, projectID, sched.ID)
+		detail := sched
+		if err := a.api.Get(scheduleURL, &detail); err != nil {
+			detail = sched
+		}
+		schedules[i] = detail.toSchedule()
+	}
+	return schedules, nil
+}
+
+// RunSchedule triggers a pipeline for the schedule immediately.
+func (a *Adapter) RunSchedule(repo domain.Repository, scheduleID string) error {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipeline_schedules/%s/play", a.baseURL, projectID, scheduleID)
+	return a.api.Post(apiURL)
+}
+
+// SetScheduleActive activates or deactivates the schedule.
+func (a *Adapter) SetScheduleActive(repo domain.Repository, scheduleID string, active bool) error {
+	projectID := url.PathEscape(repo.Owner + "/" + repo.Name)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipeline_schedules/%s?active=%t", a.baseURL, projectID, scheduleID, active)
+	return a.api.Put(apiURL)
+}
+
+type gitLabSchedule struct {
+	ID           int64  `json:"id"`
+	Description  string `json:"description"`
+	Ref          string `json:"ref"`
+	Cron         string `json:"cron"`
+	Active       bool   `json:"active"`
+	NextRunAt    string `json:"next_run_at"`
+	LastPipeline *struct {
+		ID     int64  `json:"id"`
+		Status string `json:"status"`
+	} `json:"last_pipeline"`
+}
+
+func (s gitLabSchedule) toSchedule() domain.Schedule {
+	schedule := domain.Schedule{
+		ID:          strconv.FormatInt(s.ID, 10),
+		Description: s.Description,
+		Ref:         strings.TrimPrefix(s.Ref, "refs/heads/"),
+		Cron:        s.Cron,
+		Active:      s.Active,
+	}
+	if s.Active {
+		schedule.NextRunAt, _ = time.Parse(time.RFC3339, s.NextRunAt)
+	}
+	if s.LastPipeline != nil {
+		schedule.LastStatus = mapGitLabStatus(s.LastPipeline.Status)
+		schedule.LastPipelineID = strconv.FormatInt(s.LastPipeline.ID, 10)
+	}
+	return schedule
+}
+
+type gitLabEnvironment struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	LastDeployment *struct {
+		ID        int64  `json:"id"`
+		Ref       string `json:"ref"`
+		SHA       string `json:"sha"`
+		Status    string `json:"status"`
+		CreatedAt string `json:"created_at"`
+		User      struct {
+			Username string `json:"username"`
+		} `json:"user"`
+		Deployable *struct {
+			Pipeline struct {
+				ID int64 `json:"id"`
+			} `json:"pipeline"`
+		} `json:"deployable"`
+	} `json:"last_deployment"`
+}
+
+func (e gitLabEnvironment) toEnvironment() domain.Environment {
+	env := domain.Environment{Name: e.Name}
+	if d := e.LastDeployment; d != nil {
+		created, _ := time.Parse(time.RFC3339, d.CreatedAt)
+		env.LastDeployment = &domain.Deployment{
+			ID:        strconv.FormatInt(d.ID, 10),
+			Ref:       d.Ref,
+			CommitSHA: d.SHA,
+			Status:    mapGitLabStatus(d.Status),
+			Author:    d.User.Username,
+			CreatedAt: created,
+		}
+		if d.Deployable != nil && d.Deployable.Pipeline.ID != 0 {
+			env.LastDeployment.PipelineID = strconv.FormatInt(d.Deployable.Pipeline.ID, 10)
+		}
+	}
+	return env
 }
 
 type gitLabPipeline struct {
@@ -203,8 +776,43 @@ type gitLabPipeline struct {
 	Ref       string `json:"ref"`
 	SHA       string `json:"sha"`
 	Status    string `json:"status"`
+	Source    string `json:"source"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+	// User is the user who triggered the pipeline. Only single pipeline
+	// responses include it.
+	User *struct {
+		Name     string `json:"name"`
+		Username string `json:"username"`
+	} `json:"user"`
+}
+
+type gitLabCommit struct {
+	Title      string `json:"title"`
+	AuthorName string `json:"author_name"`
+}
+
+// mergeRequestRef matches the refs GitLab runs merge request pipelines on,
+// e.g. refs/merge-requests/42/head or refs/merge-requests/42/merge.
+var mergeRequestRef = regexp.MustCompile(`^refs/merge-requests/(\d+)/`)
+
+type gitLabMergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	Draft        bool   `json:"draft"`
+}
+
+func (m gitLabMergeRequest) toMergeRequest() domain.MergeRequest {
+	return domain.MergeRequest{
+		Kind:         domain.KindMergeRequest,
+		Number:       m.IID,
+		Title:        m.Title,
+		SourceBranch: m.SourceBranch,
+		TargetBranch: m.TargetBranch,
+		Draft:        m.Draft,
+	}
 }
 
 func (r gitLabPipeline) toPipeline() domain.Pipeline {
@@ -214,14 +822,26 @@ func (r gitLabPipeline) toPipeline() domain.Pipeline {
 	if !created.IsZero() && !updated.IsZero() {
 		duration = updated.Sub(created)
 	}
-	return domain.Pipeline{
+	pipeline := domain.Pipeline{
 		ID:        strconv.FormatInt(r.ID, 10),
 		Branch:    r.Ref,
 		CommitSHA: r.SHA,
 		Status:    mapGitLabStatus(r.Status),
 		CreatedAt: created,
 		Duration:  duration,
+		Event:     r.Source,
+	}
+	if r.User != nil {
+		pipeline.Author = r.User.Name
+		if pipeline.Author == "" {
+			pipeline.Author = r.User.Username
+		}
 	}
+	if match := mergeRequestRef.FindStringSubmatch(r.Ref); match != nil {
+		iid, _ := strconv.Atoi(match[1])
+		pipeline.MergeRequest = &domain.MergeRequest{Kind: domain.KindMergeRequest, Number: iid}
+	}
+	return pipeline
 }
 
 type gitLabJob struct {
@@ -229,24 +849,111 @@ type gitLabJob struct {
 	Name       string `json:"name"`
 	Stage      string `json:"stage"`
 	Status     string `json:"status"`
+	CreatedAt  string `json:"created_at"`
 	StartedAt  string `json:"started_at"`
 	FinishedAt string `json:"finished_at"`
+	Retried    bool   `json:"retried"`
+	// QueuedDuration is the time in seconds the job waited for a runner;
+	// null for jobs that have not been queued yet.
+	QueuedDuration *float64 `json:"queued_duration"`
+	TagList        []string `json:"tag_list"`
+	// Runner is nil until a runner picks the job up.
+	Runner *gitLabRunner `json:"runner"`
+	// ArtifactsFile is nil when the job did not upload an artifact archive.
+	ArtifactsFile *struct {
+		Filename string `json:"filename"`
+		Size     int64  `json:"size"`
+	} `json:"artifacts_file"`
+	ArtifactsExpireAt string `json:"artifacts_expire_at"`
+}
+
+// gitLabBridge is a trigger job; DownstreamPipeline is nil until the
+// downstream pipeline has been created.
+type gitLabBridge struct {
+	gitLabJob
+	DownstreamPipeline *struct {
+		gitLabPipeline
+		WebURL string `json:"web_url"`
+	} `json:"downstream_pipeline"`
 }
 
 func (j gitLabJob) toJob() domain.Job {
+	queued, _ := time.Parse(time.RFC3339, j.CreatedAt)
 	started, _ := time.Parse(time.RFC3339, j.StartedAt)
 	finished, _ := time.Parse(time.RFC3339, j.FinishedAt)
 	var duration time.Duration
 	if !started.IsZero() && !finished.IsZero() {
 		duration = finished.Sub(started)
 	}
-	return domain.Job{
-		ID:        strconv.FormatInt(j.ID, 10),
-		Name:      j.Name,
-		Stage:     j.Stage,
-		Status:    mapGitLabStatus(j.Status),
-		StartedAt: started,
-		Duration:  duration,
+	// created_at is when the pipeline created the job, which for later stages
+	// is long before the job entered the queue; prefer the queue time. For a
+	// job still waiting, queued_duration is only known as of this request, so
+	// keep it as is rather than guessing when the job entered the queue.
+	var waited time.Duration
+	if j.QueuedDuration != nil {
+		waited = time.Duration(*j.QueuedDuration * float64(time.Second))
+		if !started.IsZero() {
+			queued = started.Add(-waited)
+		} else {
+			queued = time.Time{}
+		}
+	}
+	job := domain.Job{
+		ID:           strconv.FormatInt(j.ID, 10),
+		Name:         j.Name,
+		Stage:        j.Stage,
+		Status:       mapGitLabStatus(j.Status),
+		QueuedAt:     queued,
+		Queued:       waited,
+		StartedAt:    started,
+		Duration:     duration,
+		Retried:      j.Retried,
+		RunnerLabels: j.TagList,
+	}
+	if j.Runner != nil {
+		job.Runner = j.Runner.displayName()
+	}
+	return job
+}
+
+type gitLabVariable struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Masked bool   `json:"masked"`
+	Hidden bool   `json:"hidden"`
+}
+
+type gitLabRunner struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Paused      bool     `json:"paused"`
+	RunnerType  string   `json:"runner_type"`
+	TagList     []string `json:"tag_list"`
+}
+
+// displayName returns the runner's description, which is what the GitLab UI
+// shows, falling back to its name and then its ID.
+func (r gitLabRunner) displayName() string {
+	switch {
+	case r.Description != "":
+		return r.Description
+	case r.Name != "":
+		return r.Name
+	default:
+		return "#" + strconv.FormatInt(r.ID, 10)
+	}
+}
+
+func (r gitLabRunner) toRunner() domain.Runner {
+	return domain.Runner{
+		ID:     strconv.FormatInt(r.ID, 10),
+		Name:   r.displayName(),
+		Labels: r.TagList,
+		Online: r.Status == "online",
+		Paused: r.Paused,
+		Scope:  strings.TrimSuffix(r.RunnerType, "_type"),
 	}
 }
 
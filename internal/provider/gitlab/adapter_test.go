@@ -1,11 +1,13 @@
 package gitlab_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -86,7 +88,7 @@ func TestGetPipeline_ReturnsPipelineWithJobs(t *testing.T) {
 		switch r.RequestURI {
 		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201":
 			json.NewEncoder(w).Encode(pipelineResponse)
-		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/jobs":
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/jobs?include_retried=true&per_page=100":
 			json.NewEncoder(w).Encode(jobsResponse)
 		default:
 			http.NotFound(w, r)
@@ -268,3 +270,903 @@ func TestSetToken_UpdatesTokenForSubsequentRequests(t *testing.T) {
 		t.Errorf("second request: want 'Bearer new-token', got '%s'", receivedTokens[1])
 	}
 }
+
+func TestGetPipeline_ParsesJobQueuedAt(t *testing.T) {
+	queued := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": float64(201), "status": "success"})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/jobs?include_retried=true&per_page=100":
+			json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"id":          float64(301),
+				"name":        "build",
+				"stage":       "build",
+				"status":      "success",
+				"created_at":  queued.Format(time.RFC3339),
+				"started_at":  queued.Add(45 * time.Second).Format(time.RFC3339),
+				"finished_at": queued.Add(2 * time.Minute).Format(time.RFC3339),
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	pipeline, err := adapter.GetPipeline(domain.Repository{Owner: "mygroup", Name: "myproject"}, "201")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job := pipeline.Jobs[0]
+	if !job.QueuedAt.Equal(queued) {
+		t.Errorf("expected QueuedAt %s, got %s", queued, job.QueuedAt)
+	}
+	if job.StartedAt.Sub(job.QueuedAt) != 45*time.Second {
+		t.Errorf("expected 45s queue time, got %s", job.StartedAt.Sub(job.QueuedAt))
+	}
+}
+
+func TestListArtifacts_ReturnsJobArchives(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/jobs?per_page=100" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{
+					"id":                  float64(301),
+					"name":                "build",
+					"status":              "success",
+					"artifacts_file":      map[string]interface{}{"filename": "artifacts.zip", "size": float64(1024)},
+					"artifacts_expire_at": time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+				},
+				{"id": float64(302), "name": "lint", "status": "success"},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	artifacts, err := adapter.ListArtifacts(domain.Repository{Owner: "mygroup", Name: "myproject"}, "201")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(artifacts) != 1 {
+		t.Fatalf("expected 1 artifact (jobs without archives skipped), got %d", len(artifacts))
+	}
+	a := artifacts[0]
+	if a.JobID != "301" || a.Name != "build" || a.Size != 1024 {
+		t.Errorf("unexpected artifact: %+v", a)
+	}
+	if a.Expired {
+		t.Error("expected artifact not to be expired")
+	}
+}
+
+func TestDownloadArtifact_FetchesJobArchive(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawPath == "/api/v4/projects/mygroup%2Fmyproject/jobs/301/artifacts" {
+			fmt.Fprint(w, "PK-zip-bytes")
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	var buf bytes.Buffer
+	err := adapter.DownloadArtifact(domain.Repository{Owner: "mygroup", Name: "myproject"},
+		domain.Artifact{ID: "301", JobID: "301"}, &buf, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "PK-zip-bytes" {
+		t.Errorf("unexpected archive content %q", buf.String())
+	}
+}
+
+func TestGetTestReport_ReturnsFailedCases(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/test_report" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"total_count":  float64(2),
+				"failed_count": float64(1),
+				"test_suites": []map[string]interface{}{{
+					"name": "rspec",
+					"test_cases": []map[string]interface{}{
+						{"status": "success", "name": "creates a user", "classname": "UserSpec", "execution_time": 0.5},
+						{
+							"status":         "failed",
+							"name":           "deletes a user",
+							"classname":      "UserSpec",
+							"execution_time": 1.25,
+							"system_output":  "expected true\ngot false",
+							"stack_trace":    "user_spec.rb:12",
+						},
+					},
+				}},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	report, err := adapter.GetTestReport(domain.Repository{Owner: "mygroup", Name: "myproject"}, "201")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Cases) != 2 {
+		t.Fatalf("expected 2 cases, got %d", len(report.Cases))
+	}
+	failed := report.Failed()
+	if len(failed) != 1 {
+		t.Fatalf("expected 1 failed case, got %d", len(failed))
+	}
+	if failed[0].Message != "expected true" || failed[0].StackTrace != "user_spec.rb:12" {
+		t.Errorf("unexpected failed case: %+v", failed[0])
+	}
+	if failed[0].Duration != 1250*time.Millisecond {
+		t.Errorf("expected 1.25s duration, got %s", failed[0].Duration)
+	}
+}
+
+func TestGetJobAnnotations_ReturnsCodeQualityFindings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawPath == "/api/v4/projects/mygroup%2Fmyproject/jobs/301/artifacts/gl-code-quality-report.json" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"description": "Method has too many lines",
+				"check_name":  "method_lines",
+				"severity":    "minor",
+				"location": map[string]interface{}{
+					"path":  "app/models/user.rb",
+					"lines": map[string]interface{}{"begin": float64(10)},
+				},
+			}})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	annotations, err := adapter.GetJobAnnotations(domain.Repository{Owner: "mygroup", Name: "myproject"}, "301")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(annotations) != 1 {
+		t.Fatalf("expected 1 annotation, got %d", len(annotations))
+	}
+	a := annotations[0]
+	if a.Path != "app/models/user.rb" || a.StartLine != 10 || a.EndLine != 10 || a.Level != domain.AnnotationWarning {
+		t.Errorf("unexpected annotation: %+v", a)
+	}
+}
+
+func TestGetJobAnnotations_NoReportReturnsEmpty(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	annotations, err := adapter.GetJobAnnotations(domain.Repository{Owner: "mygroup", Name: "myproject"}, "301")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(annotations) != 0 {
+		t.Errorf("expected no annotations, got %d", len(annotations))
+	}
+}
+
+func TestListPipelines_AddsMergeRequestDetails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.RawPath {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": float64(202), "ref": "refs/merge-requests/7/head", "status": "running"},
+				{"id": float64(201), "ref": "main", "status": "success"},
+			})
+		case "/api/v4/projects/mygroup%2Fmyproject/merge_requests/7":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"iid":           float64(7),
+				"title":         "Draft: Add login page",
+				"source_branch": "feature/login",
+				"target_branch": "main",
+				"draft":         true,
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	pipelines, err := adapter.ListPipelines(domain.Repository{Owner: "mygroup", Name: "myproject"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pipelines[1].MergeRequest != nil {
+		t.Errorf("expected branch pipeline without merge request, got %+v", pipelines[1].MergeRequest)
+	}
+	mr := pipelines[0].MergeRequest
+	if mr == nil {
+		t.Fatal("expected pipeline to reference a merge request")
+	}
+	want := domain.MergeRequest{Kind: domain.KindMergeRequest, Number: 7, Title: "Draft: Add login page", SourceBranch: "feature/login", TargetBranch: "main", Draft: true}
+	if *mr != want {
+		t.Errorf("expected %+v, got %+v", want, *mr)
+	}
+	if pipelines[0].Branch != "feature/login" {
+		t.Errorf("expected branch 'feature/login', got '%s'", pipelines[0].Branch)
+	}
+}
+
+func TestMergeRequestDetails_RefreshedByDetailAndFailuresNotRetriedEachTime(t *testing.T) {
+	title, mergeRequestCalls := "", 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		pipeline := map[string]interface{}{"id": float64(202), "ref": "refs/merge-requests/7/head", "status": "running"}
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines?per_page=3":
+			json.NewEncoder(w).Encode([]map[string]interface{}{pipeline})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/202":
+			json.NewEncoder(w).Encode(pipeline)
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/202/jobs?include_retried=true&per_page=100":
+			json.NewEncoder(w).Encode([]interface{}{})
+		case "/api/v4/projects/mygroup%2Fmyproject/merge_requests/7":
+			mergeRequestCalls++
+			if title == "" {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"iid": float64(7), "title": title, "source_branch": "feature/login"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	repo := domain.Repository{Owner: "mygroup", Name: "myproject"}
+	for i := 0; i < 2; i++ {
+		if _, err := adapter.ListPipelines(repo); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if mergeRequestCalls != 1 {
+		t.Errorf("expected a failed lookup not to be retried right away, got %d calls", mergeRequestCalls)
+	}
+
+	title = "Add login page"
+	pipeline, err := adapter.GetPipeline(repo, "202")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pipeline.MergeRequest.Title != "Add login page" {
+		t.Errorf("expected the detail to fetch the merge request again, got %+v", pipeline.MergeRequest)
+	}
+	title = "Add login and logout pages"
+	adapter.GetPipeline(repo, "202")
+	pipelines, _ := adapter.ListPipelines(repo)
+	if pipelines[0].MergeRequest.Title != "Add login and logout pages" {
+		t.Errorf("expected the list to show the refreshed title, got %+v", pipelines[0].MergeRequest)
+	}
+}
+
+func TestListMergeRequestPipelines_UsesMergeRequestEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawPath == "/api/v4/projects/mygroup%2Fmyproject/merge_requests/7/pipelines" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": float64(202), "ref": "refs/merge-requests/7/head", "status": "running"},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	pipelines, err := adapter.ListMergeRequestPipelines(
+		domain.Repository{Owner: "mygroup", Name: "myproject"},
+		domain.MergeRequest{Number: 7},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pipelines) != 1 || pipelines[0].MergeRequest == nil || pipelines[0].MergeRequest.Number != 7 {
+		t.Errorf("expected pipeline of merge request 7, got %+v", pipelines)
+	}
+}
+
+func TestGetPipeline_IncludesBridgeJobsWithDownstreamPipelines(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.RawPath {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": float64(201), "ref": "main", "status": "running"})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/jobs":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": float64(301), "name": "build", "stage": "build", "status": "success"},
+			})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/bridges":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{
+					"id": float64(302), "name": "child", "stage": "test", "status": "running",
+					"downstream_pipeline": map[string]interface{}{
+						"id": float64(210), "ref": "main", "status": "running",
+						"web_url": srvURL(r) + "/mygroup/myproject/-/pipelines/210",
+					},
+				},
+				{
+					"id": float64(303), "name": "deploy", "stage": "deploy", "status": "success",
+					"downstream_pipeline": map[string]interface{}{
+						"id": float64(900), "ref": "main", "status": "success",
+						"web_url": srvURL(r) + "/ops/infra/deploy/-/pipelines/900",
+					},
+				},
+				{"id": float64(304), "name": "later", "stage": "deploy", "status": "created"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	repo := domain.Repository{Owner: "mygroup", Name: "myproject", RemoteURL: "git@gitlab.com:mygroup/myproject.git"}
+	pipeline, err := adapter.GetPipeline(repo, "201")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pipeline.Jobs) != 4 {
+		t.Fatalf("expected 4 jobs including bridges, got %d", len(pipeline.Jobs))
+	}
+	if pipeline.Jobs[0].Downstream != nil || pipeline.Jobs[3].Downstream != nil {
+		t.Error("expected no downstream pipeline for regular or untriggered jobs")
+	}
+	child := pipeline.Jobs[1].Downstream
+	if child == nil || child.Pipeline.ID != "210" || child.Pipeline.Status != domain.StatusRunning {
+		t.Fatalf("unexpected child pipeline: %+v", child)
+	}
+	if child.Repository != repo {
+		t.Errorf("expected child pipeline in the upstream repository, got %+v", child.Repository)
+	}
+	multi := pipeline.Jobs[2].Downstream
+	if multi == nil || multi.Pipeline.ID != "900" {
+		t.Fatalf("unexpected multi-project pipeline: %+v", multi)
+	}
+	if multi.Repository.Owner != "ops/infra" || multi.Repository.Name != "deploy" {
+		t.Errorf("expected repository ops/infra/deploy, got %+v", multi.Repository)
+	}
+}
+
+// srvURL returns the base URL of the test server handling r.
+func srvURL(r *http.Request) string {
+	return "http://" + r.Host
+}
+
+func TestGetPipeline_NumbersRetriedJobAttempts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": float64(201), "status": "success"})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/jobs?include_retried=true&per_page=100":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": float64(305), "name": "test", "status": "success"},
+				{"id": float64(301), "name": "build", "status": "success"},
+				{"id": float64(302), "name": "test", "status": "failed", "retried": true},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	pipeline, err := adapter.GetPipeline(domain.Repository{Owner: "mygroup", Name: "myproject"}, "201")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]struct {
+		attempt int
+		retried bool
+	}{
+		"305": {2, false},
+		"301": {1, false},
+		"302": {1, true},
+	}
+	for _, j := range pipeline.Jobs {
+		if w := want[j.ID]; j.Attempt != w.attempt || j.Retried != w.retried {
+			t.Errorf("job %s: expected attempt %d retried %v, got attempt %d retried %v",
+				j.ID, w.attempt, w.retried, j.Attempt, j.Retried)
+		}
+	}
+}
+
+func TestListEnvironments_ReturnsLastDeploymentLinkedToPipeline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/environments?states=available&per_page=100":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": float64(5), "name": "staging"},
+				{"id": float64(6), "name": "production"},
+			})
+		case "/api/v4/projects/mygroup%2Fmyproject/environments/6":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/api/v4/projects/mygroup%2Fmyproject/environments/5":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": float64(5), "name": "staging",
+				"last_deployment": map[string]interface{}{
+					"id": float64(90), "ref": "main", "sha": "def5678", "status": "failed",
+					"created_at": "2026-01-01T10:00:00Z",
+					"user":       map[string]interface{}{"username": "waabox"},
+					"deployable": map[string]interface{}{"pipeline": map[string]interface{}{"id": float64(201)}},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	environments, err := adapter.ListEnvironments(domain.Repository{Owner: "mygroup", Name: "myproject"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(environments) != 2 || environments[0].Name != "staging" {
+		t.Fatalf("unexpected environments: %+v", environments)
+	}
+	d := environments[0].LastDeployment
+	if d == nil || d.Status != domain.StatusFailed || d.Author != "waabox" || d.PipelineID != "201" {
+		t.Errorf("unexpected deployment: %+v", d)
+	}
+	if env := environments[1]; env.Name != "production" || env.LastDeployment != nil {
+		t.Errorf("expected an unreadable environment listed without its deployment, got %+v", env)
+	}
+}
+
+func TestListSchedules_ReturnsNextRunAndLastPipeline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.RawPath {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipeline_schedules":
+			json.NewEncoder(w).Encode([]map[string]interface{}{{"id": float64(13), "description": "nightly"}})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipeline_schedules/13":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": float64(13), "description": "nightly", "ref": "refs/heads/main",
+				"cron": "0 3 * * *", "active": true, "next_run_at": "2026-01-02T03:00:00Z",
+				"last_pipeline": map[string]interface{}{"id": float64(301), "status": "success"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	schedules, err := adapter.ListSchedules(domain.Repository{Owner: "mygroup", Name: "myproject"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schedules) != 1 {
+		t.Fatalf("expected 1 schedule, got %d", len(schedules))
+	}
+	s := schedules[0]
+	if s.ID != "13" || s.Ref != "main" || s.Cron != "0 3 * * *" || !s.Active {
+		t.Errorf("unexpected schedule: %+v", s)
+	}
+	if !s.NextRunAt.Equal(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected next run: %v", s.NextRunAt)
+	}
+	if s.LastStatus != domain.StatusSuccess || s.LastPipelineID != "301" {
+		t.Errorf("unexpected last run: %+v", s)
+	}
+}
+
+func TestListSchedules_FollowsPagesAndKeepsUnreadableSchedules(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipeline_schedules?per_page=100":
+			w.Header().Set("X-Next-Page", "2")
+			json.NewEncoder(w).Encode([]map[string]interface{}{{"id": float64(13), "description": "nightly"}})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipeline_schedules?page=2&per_page=100":
+			w.Header().Set("X-Next-Page", "")
+			json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"id": float64(14), "description": "weekly", "ref": "main", "cron": "0 4 * * 0", "active": true,
+			}})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipeline_schedules/13":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": float64(13), "description": "nightly", "ref": "main", "cron": "0 3 * * *",
+				"last_pipeline": map[string]interface{}{"id": float64(301), "status": "success"},
+			})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipeline_schedules/14":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	schedules, err := adapter.ListSchedules(domain.Repository{Owner: "mygroup", Name: "myproject"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schedules) != 2 || schedules[0].LastPipelineID != "301" {
+		t.Fatalf("expected the schedules of both pages, got %+v", schedules)
+	}
+	if s := schedules[1]; s.Description != "weekly" || s.Cron != "0 4 * * 0" || s.LastPipelineID != "" {
+		t.Errorf("expected an unreadable schedule listed without its last pipeline, got %+v", s)
+	}
+}
+
+func TestRunSchedule_PostsPlay(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.RawPath == "/api/v4/projects/waabox%2Fgitdeck/pipeline_schedules/13/play" {
+			called = true
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	if err := adapter.RunSchedule(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "13"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected play endpoint to be called")
+	}
+}
+
+func TestSetScheduleActive_PutsActiveFlag(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && r.RequestURI == "/api/v4/projects/waabox%2Fgitdeck/pipeline_schedules/13?active=false" {
+			called = true
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	if err := adapter.SetScheduleActive(domain.Repository{Owner: "waabox", Name: "gitdeck"}, "13", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected schedule to be deactivated")
+	}
+}
+
+func TestGetPipeline_ParsesJobRunnerAndQueuedDuration(t *testing.T) {
+	started := time.Date(2026, 1, 1, 10, 5, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": float64(201), "status": "running"})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/jobs?include_retried=true&per_page=100":
+			json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"id": float64(301), "name": "build", "stage": "build", "status": "running",
+				"created_at":      "2026-01-01T10:00:00Z",
+				"started_at":      started.Format(time.RFC3339),
+				"queued_duration": 12.5,
+				"tag_list":        []string{"docker"},
+				"runner":          map[string]interface{}{"id": float64(7), "description": "shared-runner-1"},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	pipeline, err := adapter.GetPipeline(domain.Repository{Owner: "mygroup", Name: "myproject"}, "201")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job := pipeline.Jobs[0]
+	if job.Runner != "shared-runner-1" || len(job.RunnerLabels) != 1 || job.RunnerLabels[0] != "docker" {
+		t.Errorf("unexpected runner: %q %v", job.Runner, job.RunnerLabels)
+	}
+	if got := job.QueuedDuration(time.Now()); got != 12500*time.Millisecond {
+		t.Errorf("expected 12.5s queued, got %s", got)
+	}
+}
+
+func TestGetPipeline_KeepsQueuedDurationOfWaitingJob(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": float64(201), "status": "pending"})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/jobs?include_retried=true&per_page=100":
+			json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"id": float64(301), "name": "build", "stage": "build", "status": "pending",
+				"created_at":      "2026-01-01T10:00:00Z",
+				"queued_duration": 90.0,
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	pipeline, err := adapter.GetPipeline(domain.Repository{Owner: "mygroup", Name: "myproject"}, "201")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job := pipeline.Jobs[0]
+	if !job.QueuedAt.IsZero() || job.Queued != 90*time.Second {
+		t.Errorf("expected the raw 90s queue time, got QueuedAt %s and Queued %s", job.QueuedAt, job.Queued)
+	}
+	if got := job.QueuedDuration(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)); got != 90*time.Second {
+		t.Errorf("expected 90s queued, got %s", got)
+	}
+}
+
+func TestListRunners_ReturnsRunnersWithTags(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/runners?per_page=100":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": float64(7), "description": "group-runner", "status": "online", "runner_type": "group_type"},
+				{"id": float64(8), "description": "shared", "status": "offline", "runner_type": "instance_type"},
+			})
+		case "/api/v4/runners/7":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": float64(7), "description": "group-runner", "status": "online",
+				"runner_type": "group_type", "tag_list": []string{"docker", "linux"},
+			})
+		default:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	runners, err := adapter.ListRunners(domain.Repository{Owner: "mygroup", Name: "myproject"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runners) != 2 {
+		t.Fatalf("expected 2 runners, got %d", len(runners))
+	}
+	if r := runners[0]; !r.Online || r.Scope != "group" || len(r.Labels) != 2 {
+		t.Errorf("unexpected group runner: %+v", r)
+	}
+	if r := runners[1]; r.Online || r.Scope != "instance" || r.Name != "shared" {
+		t.Errorf("expected shared runner listed without tags, got %+v", r)
+	}
+}
+
+func TestGetPipelineVariables_ReturnsVariablesWithoutMaskedValues(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/variables":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"key": "DEPLOY_ENV", "value": "staging"},
+				{"key": "API_TOKEN", "value": "s3cr3t"},
+				{"key": "REGISTRY_PASSWORD", "value": "hunter2"},
+			})
+		case "/api/v4/projects/mygroup%2Fmyproject/variables?per_page=100":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"key": "API_TOKEN", "value": "s3cr3t", "masked": true},
+			})
+		case "/api/v4/groups/mygroup/variables?per_page=100":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"key": "REGISTRY_PASSWORD", "value": "hunter2", "masked": true},
+			})
+		case "/api/v4/admin/ci/variables?per_page=100":
+			// Only administrators may read instance variables.
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	variables, err := adapter.GetPipelineVariables(domain.Repository{Owner: "mygroup", Name: "myproject"}, "201")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variables.ValuesHidden != "" {
+		t.Errorf("expected an unreadable instance level to hide no values, got %q", variables.ValuesHidden)
+	}
+	want := []domain.Variable{
+		{Key: "DEPLOY_ENV", Value: "staging"},
+		{Key: "API_TOKEN", Masked: true},
+		{Key: "REGISTRY_PASSWORD", Masked: true},
+	}
+	if len(variables.Variables) != len(want) {
+		t.Fatalf("expected %d variables, got %+v", len(want), variables.Variables)
+	}
+	for i, v := range want {
+		if variables.Variables[i] != v {
+			t.Errorf("variable %d: expected %+v, got %+v", i, v, variables.Variables[i])
+		}
+	}
+}
+
+func TestGetPipelineVariables_HidesValuesWhenMaskedKeysAreUnknown(t *testing.T) {
+	projectLookups := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/variables":
+			json.NewEncoder(w).Encode([]map[string]interface{}{{"key": "DEPLOY_ENV", "value": "staging"}})
+		case "/api/v4/projects/mygroup%2Fmyproject/variables?per_page=100":
+			// A Developer may read the pipeline's variables but not the project's.
+			projectLookups++
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	repo := domain.Repository{Owner: "mygroup", Name: "myproject"}
+	for i := 0; i < 2; i++ {
+		variables, err := adapter.GetPipelineVariables(repo, "201")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := domain.Variable{Key: "DEPLOY_ENV", Masked: true}
+		if len(variables.Variables) != 1 || variables.Variables[0] != want {
+			t.Errorf("expected the value hidden while masked keys are unknown, got %+v", variables.Variables)
+		}
+		if !strings.Contains(variables.ValuesHidden, "Maintainer role") {
+			t.Errorf("expected the reason values are hidden, got %q", variables.ValuesHidden)
+		}
+	}
+	if projectLookups != 1 {
+		t.Errorf("expected the failed lookup to be cached, got %d lookups", projectLookups)
+	}
+}
+
+func TestListCommitChecks_ReturnsCommitStatuses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.RequestURI != "/api/v4/projects/mygroup%2Fmyproject/repository/commits/abc1234/statuses?per_page=100" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"name": "build", "status": "success", "target_url": "https://gitlab.com/mygroup/myproject/-/jobs/1"},
+			{"name": "ci/jenkins", "status": "running", "description": "Build #12",
+				"author": map[string]interface{}{"username": "jenkins-bot"}},
+		})
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	checks, err := adapter.ListCommitChecks(domain.Repository{Owner: "mygroup", Name: "myproject"}, "abc1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(checks) != 2 {
+		t.Fatalf("expected 2 checks, got %d", len(checks))
+	}
+	if c := checks[1]; c.Name != "ci/jenkins" || c.Status != domain.StatusRunning || c.Source != "jenkins-bot" || c.Kind != domain.CommitStatus {
+		t.Errorf("unexpected external status: %+v", c)
+	}
+}
+
+func TestListPipelines_FillsCommitMessageAndAuthorFromCachedCommits(t *testing.T) {
+	commitCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines?per_page=3":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": float64(202), "ref": "main", "sha": "def5678", "status": "success"},
+				{"id": float64(201), "ref": "main", "sha": "def5678", "status": "failed"},
+			})
+		case "/api/v4/projects/mygroup%2Fmyproject/repository/commits/def5678":
+			commitCalls++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"title": "fix: handle empty config", "author_name": "Emiliano Arango",
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	repo := domain.Repository{Owner: "mygroup", Name: "myproject"}
+	for i := 0; i < 2; i++ {
+		pipelines, err := adapter.ListPipelines(repo)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, p := range pipelines {
+			if p.CommitMsg != "fix: handle empty config" || p.CommitAuthor != "Emiliano Arango" || p.Author != "" {
+				t.Errorf("unexpected commit details: %q by %q, run by %q", p.CommitMsg, p.CommitAuthor, p.Author)
+			}
+		}
+	}
+	if commitCalls != 1 {
+		t.Errorf("expected the commit to be fetched once, got %d calls", commitCalls)
+	}
+}
+
+func TestGetPipeline_PrefersTriggeringUserAsAuthor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": float64(201), "sha": "def5678", "status": "success",
+				"user": map[string]interface{}{"name": "Release Bot", "username": "release-bot"},
+			})
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines/201/jobs?include_retried=true&per_page=100":
+			json.NewEncoder(w).Encode([]map[string]interface{}{})
+		case "/api/v4/projects/mygroup%2Fmyproject/repository/commits/def5678":
+			json.NewEncoder(w).Encode(map[string]interface{}{"title": "chore: release 1.2.0", "author_name": "waabox"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	pipeline, err := adapter.GetPipeline(domain.Repository{Owner: "mygroup", Name: "myproject"}, "201")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pipeline.Author != "Release Bot" || pipeline.CommitMsg != "chore: release 1.2.0" || pipeline.CommitAuthor != "waabox" {
+		t.Errorf("unexpected header info: %q by %q, run by %q", pipeline.CommitMsg, pipeline.CommitAuthor, pipeline.Author)
+	}
+}
+
+func TestListPipelines_DoesNotRetryFailedCommitLookupsEachTime(t *testing.T) {
+	commitCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/v4/projects/mygroup%2Fmyproject/pipelines?per_page=3":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": float64(202), "ref": "main", "sha": "def5678", "status": "success"},
+			})
+		case "/api/v4/projects/mygroup%2Fmyproject/repository/commits/def5678":
+			commitCalls++
+			http.NotFound(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	adapter := gitlabprovider.NewAdapter("test-token", srv.URL, 3)
+	repo := domain.Repository{Owner: "mygroup", Name: "myproject"}
+	for i := 0; i < 3; i++ {
+		if _, err := adapter.ListPipelines(repo); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if commitCalls != 1 {
+		t.Errorf("expected a failed lookup not to be retried right away, got %d calls", commitCalls)
+	}
+}
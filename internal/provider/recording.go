@@ -0,0 +1,351 @@
+// internal/provider/recording.go
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// RecordingProvider wraps a PipelineProvider and records every response it
+// returns, so that the session can be served back by a ReplayProvider.
+// It forwards every optional capability; calls the wrapped provider does not
+// support are recorded as domain.ErrNotSupported.
+//
+// Consecutive identical responses to the same call, such as the list polled
+// while nothing changes, are recorded once, and artifact archives larger
+// than maxRecordedArchive are not recorded at all.
+type RecordingProvider struct {
+	inner domain.PipelineProvider
+
+	mu      sync.Mutex
+	fixture Fixture
+	// last indexes the latest recorded response to each call in fixture.Calls.
+	last map[string]int
+}
+
+// maxRecordedArchive is the size of the largest artifact archive recorded.
+const maxRecordedArchive = 1 << 20
+
+// Ensure RecordingProvider implements PipelineProvider and forwards every
+// optional capability of the providers it wraps.
+var (
+	_ domain.PipelineProvider             = (*RecordingProvider)(nil)
+	_ domain.ArtifactProvider             = (*RecordingProvider)(nil)
+	_ domain.TestReportProvider           = (*RecordingProvider)(nil)
+	_ domain.VariableProvider             = (*RecordingProvider)(nil)
+	_ domain.AnnotationProvider           = (*RecordingProvider)(nil)
+	_ domain.MergeRequestPipelineProvider = (*RecordingProvider)(nil)
+	_ domain.WorkflowPipelineProvider     = (*RecordingProvider)(nil)
+	_ domain.AttemptProvider              = (*RecordingProvider)(nil)
+	_ domain.EnvironmentProvider          = (*RecordingProvider)(nil)
+	_ domain.RunnerProvider               = (*RecordingProvider)(nil)
+	_ domain.CommitCheckProvider          = (*RecordingProvider)(nil)
+	_ domain.RateLimitReporter            = (*RecordingProvider)(nil)
+	_ domain.ScheduleProvider             = (*RecordingProvider)(nil)
+	_ domain.ScheduleController           = (*RecordingProvider)(nil)
+)
+
+// NewRecordingProvider creates a RecordingProvider for calls about repo.
+// Credentials embedded in the remote URL are not recorded.
+func NewRecordingProvider(inner domain.PipelineProvider, repo domain.Repository) *RecordingProvider {
+	if u, err := url.Parse(repo.RemoteURL); err == nil && u.User != nil {
+		u.User = nil
+		repo.RemoteURL = u.String()
+	}
+	return &RecordingProvider{inner: inner, fixture: Fixture{Repository: repo}, last: make(map[string]int)}
+}
+
+// Save writes the calls recorded so far to path.
+func (rec *RecordingProvider) Save(path string) error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return SaveFixture(path, rec.fixture)
+}
+
+// record calls fn and appends its result to the fixture under method and key.
+func record[T any](rec *RecordingProvider, method, key string, fn func() (T, error)) (T, error) {
+	result, err := fn()
+	call := RecordedCall{Method: method, Key: key}
+	if err != nil {
+		call.Error = newRecordedError(err)
+	} else if data, marshalErr := json.Marshal(result); marshalErr == nil {
+		call.Result = data
+	}
+	rec.append(call)
+	return result, err
+}
+
+// append adds call to the fixture unless it repeats the latest response to
+// the same call, which a replay would serve again anyway.
+func (rec *RecordingProvider) append(call RecordedCall) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	id := callID(call.Method, call.Key)
+	if i, ok := rec.last[id]; ok && sameResponse(rec.fixture.Calls[i], call) {
+		return
+	}
+	rec.last[id] = len(rec.fixture.Calls)
+	rec.fixture.Calls = append(rec.fixture.Calls, call)
+}
+
+func sameResponse(a, b RecordedCall) bool {
+	if (a.Error == nil) != (b.Error == nil) {
+		return false
+	}
+	if a.Error != nil && *a.Error != *b.Error {
+		return false
+	}
+	return bytes.Equal(a.Result, b.Result)
+}
+
+// recordAction records an action that returns no result.
+func recordAction(rec *RecordingProvider, method, key string, fn func() error) error {
+	_, err := record(rec, method, key, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+func (rec *RecordingProvider) ListPipelines(repo domain.Repository) ([]domain.Pipeline, error) {
+	return record(rec, "ListPipelines", "", func() ([]domain.Pipeline, error) {
+		return rec.inner.ListPipelines(repo)
+	})
+}
+
+func (rec *RecordingProvider) GetPipeline(repo domain.Repository, id domain.PipelineID) (domain.Pipeline, error) {
+	return record(rec, "GetPipeline", string(id), func() (domain.Pipeline, error) {
+		return rec.inner.GetPipeline(repo, id)
+	})
+}
+
+func (rec *RecordingProvider) GetJobLogs(repo domain.Repository, jobID domain.JobID) (string, error) {
+	return record(rec, "GetJobLogs", string(jobID), func() (string, error) {
+		return rec.inner.GetJobLogs(repo, jobID)
+	})
+}
+
+func (rec *RecordingProvider) RerunPipeline(repo domain.Repository, id domain.PipelineID) error {
+	return recordAction(rec, "RerunPipeline", string(id), func() error {
+		return rec.inner.RerunPipeline(repo, id)
+	})
+}
+
+func (rec *RecordingProvider) CancelPipeline(repo domain.Repository, id domain.PipelineID) error {
+	return recordAction(rec, "CancelPipeline", string(id), func() error {
+		return rec.inner.CancelPipeline(repo, id)
+	})
+}
+
+// ListArtifacts records the artifacts of the wrapped domain.ArtifactProvider.
+func (rec *RecordingProvider) ListArtifacts(repo domain.Repository, id domain.PipelineID) ([]domain.Artifact, error) {
+	return record(rec, "ListArtifacts", string(id), func() ([]domain.Artifact, error) {
+		inner, ok := rec.inner.(domain.ArtifactProvider)
+		if !ok {
+			return nil, domain.ErrNotSupported
+		}
+		return inner.ListArtifacts(repo, id)
+	})
+}
+
+// DownloadArtifact records the archive downloaded from the wrapped
+// domain.ArtifactProvider while streaming it to dst. Archives larger than
+// maxRecordedArchive are recorded as an error, so a replay reports them
+// as missing instead of keeping them in memory and in the fixture.
+func (rec *RecordingProvider) DownloadArtifact(repo domain.Repository, artifact domain.Artifact, dst io.Writer, progress func(written, total int64)) error {
+	call := RecordedCall{Method: "DownloadArtifact", Key: artifact.ID}
+	inner, ok := rec.inner.(domain.ArtifactProvider)
+	if !ok {
+		call.Error = newRecordedError(domain.ErrNotSupported)
+		rec.append(call)
+		return domain.ErrNotSupported
+	}
+	archive := &cappedBuffer{limit: maxRecordedArchive}
+	err := inner.DownloadArtifact(repo, artifact, io.MultiWriter(dst, archive), progress)
+	switch {
+	case err != nil:
+		call.Error = newRecordedError(err)
+	case archive.truncated:
+		call.Error = &RecordedError{Message: fmt.Sprintf("artifact archive larger than %d bytes was not recorded", maxRecordedArchive)}
+	default:
+		call.Result, _ = json.Marshal(archive.buf.Bytes())
+	}
+	rec.append(call)
+	return err
+}
+
+// cappedBuffer buffers up to limit bytes and notes whether more were written.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.truncated || b.buf.Len()+len(p) > b.limit {
+		b.truncated = true
+		b.buf.Reset()
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+// GetTestReport records the report of the wrapped domain.TestReportProvider.
+func (rec *RecordingProvider) GetTestReport(repo domain.Repository, id domain.PipelineID) (domain.TestReport, error) {
+	return record(rec, "GetTestReport", string(id), func() (domain.TestReport, error) {
+		inner, ok := rec.inner.(domain.TestReportProvider)
+		if !ok {
+			return domain.TestReport{}, domain.ErrNotSupported
+		}
+		return inner.GetTestReport(repo, id)
+	})
+}
+
+// GetPipelineVariables records the variables of the wrapped domain.VariableProvider.
+func (rec *RecordingProvider) GetPipelineVariables(repo domain.Repository, id domain.PipelineID) (domain.PipelineVariables, error) {
+	return record(rec, "GetPipelineVariables", string(id), func() (domain.PipelineVariables, error) {
+		inner, ok := rec.inner.(domain.VariableProvider)
+		if !ok {
+			return domain.PipelineVariables{}, domain.ErrNotSupported
+		}
+		return inner.GetPipelineVariables(repo, id)
+	})
+}
+
+// GetJobAnnotations records the annotations of the wrapped domain.AnnotationProvider.
+func (rec *RecordingProvider) GetJobAnnotations(repo domain.Repository, jobID domain.JobID) ([]domain.Annotation, error) {
+	return record(rec, "GetJobAnnotations", string(jobID), func() ([]domain.Annotation, error) {
+		inner, ok := rec.inner.(domain.AnnotationProvider)
+		if !ok {
+			return nil, domain.ErrNotSupported
+		}
+		return inner.GetJobAnnotations(repo, jobID)
+	})
+}
+
+// ListMergeRequestPipelines records the pipelines of the wrapped
+// domain.MergeRequestPipelineProvider.
+func (rec *RecordingProvider) ListMergeRequestPipelines(repo domain.Repository, mr domain.MergeRequest) ([]domain.Pipeline, error) {
+	return record(rec, "ListMergeRequestPipelines", strconv.Itoa(mr.Number), func() ([]domain.Pipeline, error) {
+		inner, ok := rec.inner.(domain.MergeRequestPipelineProvider)
+		if !ok {
+			return nil, domain.ErrNotSupported
+		}
+		return inner.ListMergeRequestPipelines(repo, mr)
+	})
+}
+
+// ListWorkflowPipelines records the pipelines of the wrapped
+// domain.WorkflowPipelineProvider.
+func (rec *RecordingProvider) ListWorkflowPipelines(repo domain.Repository, workflowID string) ([]domain.Pipeline, error) {
+	return record(rec, "ListWorkflowPipelines", workflowID, func() ([]domain.Pipeline, error) {
+		inner, ok := rec.inner.(domain.WorkflowPipelineProvider)
+		if !ok {
+			return nil, domain.ErrNotSupported
+		}
+		return inner.ListWorkflowPipelines(repo, workflowID)
+	})
+}
+
+// GetPipelineAttempt records the attempt returned by the wrapped domain.AttemptProvider.
+func (rec *RecordingProvider) GetPipelineAttempt(repo domain.Repository, id domain.PipelineID, attempt int) (domain.Pipeline, error) {
+	return record(rec, "GetPipelineAttempt", attemptKey(id, attempt), func() (domain.Pipeline, error) {
+		inner, ok := rec.inner.(domain.AttemptProvider)
+		if !ok {
+			return domain.Pipeline{}, domain.ErrNotSupported
+		}
+		return inner.GetPipelineAttempt(repo, id, attempt)
+	})
+}
+
+// ListEnvironments records the environments of the wrapped domain.EnvironmentProvider.
+func (rec *RecordingProvider) ListEnvironments(repo domain.Repository) ([]domain.Environment, error) {
+	return record(rec, "ListEnvironments", "", func() ([]domain.Environment, error) {
+		inner, ok := rec.inner.(domain.EnvironmentProvider)
+		if !ok {
+			return nil, domain.ErrNotSupported
+		}
+		return inner.ListEnvironments(repo)
+	})
+}
+
+// RateLimit forwards to the wrapped provider if it implements domain.RateLimitReporter.
+// The quota is not recorded.
+func (rec *RecordingProvider) RateLimit() (domain.RateLimit, bool) {
+	inner, ok := rec.inner.(domain.RateLimitReporter)
+	if !ok {
+		return domain.RateLimit{}, false
+	}
+	return inner.RateLimit()
+}
+
+// ListCommitChecks records the checks of the wrapped domain.CommitCheckProvider.
+func (rec *RecordingProvider) ListCommitChecks(repo domain.Repository, sha string) ([]domain.CommitCheck, error) {
+	return record(rec, "ListCommitChecks", sha, func() ([]domain.CommitCheck, error) {
+		inner, ok := rec.inner.(domain.CommitCheckProvider)
+		if !ok {
+			return nil, domain.ErrNotSupported
+		}
+		return inner.ListCommitChecks(repo, sha)
+	})
+}
+
+// ListRunners records the runners of the wrapped domain.RunnerProvider.
+func (rec *RecordingProvider) ListRunners(repo domain.Repository) ([]domain.Runner, error) {
+	return record(rec, "ListRunners", "", func() ([]domain.Runner, error) {
+		inner, ok := rec.inner.(domain.RunnerProvider)
+		if !ok {
+			return nil, domain.ErrNotSupported
+		}
+		return inner.ListRunners(repo)
+	})
+}
+
+// ListSchedules records the schedules of the wrapped domain.ScheduleProvider.
+func (rec *RecordingProvider) ListSchedules(repo domain.Repository) ([]domain.Schedule, error) {
+	return record(rec, "ListSchedules", "", func() ([]domain.Schedule, error) {
+		inner, ok := rec.inner.(domain.ScheduleProvider)
+		if !ok {
+			return nil, domain.ErrNotSupported
+		}
+		return inner.ListSchedules(repo)
+	})
+}
+
+// RunSchedule records the outcome of the wrapped domain.ScheduleController.
+func (rec *RecordingProvider) RunSchedule(repo domain.Repository, scheduleID string) error {
+	return recordAction(rec, "RunSchedule", scheduleID, func() error {
+		inner, ok := rec.inner.(domain.ScheduleController)
+		if !ok {
+			return domain.ErrNotSupported
+		}
+		return inner.RunSchedule(repo, scheduleID)
+	})
+}
+
+// SetScheduleActive records the outcome of the wrapped domain.ScheduleController.
+func (rec *RecordingProvider) SetScheduleActive(repo domain.Repository, scheduleID string, active bool) error {
+	return recordAction(rec, "SetScheduleActive", scheduleActiveKey(scheduleID, active), func() error {
+		inner, ok := rec.inner.(domain.ScheduleController)
+		if !ok {
+			return domain.ErrNotSupported
+		}
+		return inner.SetScheduleActive(repo, scheduleID, active)
+	})
+}
+
+// attemptKey identifies an attempt of a pipeline in a fixture.
+func attemptKey(id domain.PipelineID, attempt int) string {
+	return fmt.Sprintf("%s/%d", id, attempt)
+}
+
+// scheduleActiveKey identifies a schedule state change in a fixture.
+func scheduleActiveKey(scheduleID string, active bool) string {
+	return fmt.Sprintf("%s/%t", scheduleID, active)
+}
@@ -4,6 +4,7 @@ package provider
 import (
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/waabox/gitdeck/internal/domain"
 )
@@ -27,8 +28,24 @@ type RefreshingProvider struct {
 	updateToken func(string)
 }
 
-// Ensure RefreshingProvider implements PipelineProvider.
-var _ domain.PipelineProvider = (*RefreshingProvider)(nil)
+// Ensure RefreshingProvider implements PipelineProvider and forwards every
+// optional capability of the providers it wraps.
+var (
+	_ domain.PipelineProvider             = (*RefreshingProvider)(nil)
+	_ domain.ArtifactProvider             = (*RefreshingProvider)(nil)
+	_ domain.TestReportProvider           = (*RefreshingProvider)(nil)
+	_ domain.VariableProvider             = (*RefreshingProvider)(nil)
+	_ domain.AnnotationProvider           = (*RefreshingProvider)(nil)
+	_ domain.MergeRequestPipelineProvider = (*RefreshingProvider)(nil)
+	_ domain.WorkflowPipelineProvider     = (*RefreshingProvider)(nil)
+	_ domain.AttemptProvider              = (*RefreshingProvider)(nil)
+	_ domain.EnvironmentProvider          = (*RefreshingProvider)(nil)
+	_ domain.RunnerProvider               = (*RefreshingProvider)(nil)
+	_ domain.CommitCheckProvider          = (*RefreshingProvider)(nil)
+	_ domain.RateLimitReporter            = (*RefreshingProvider)(nil)
+	_ domain.ScheduleProvider             = (*RefreshingProvider)(nil)
+	_ domain.ScheduleController           = (*RefreshingProvider)(nil)
+)
 
 // NewRefreshingProvider creates a RefreshingProvider.
 // refreshFn is called on 401 to attempt a silent token refresh; returns new access token.
@@ -56,73 +73,223 @@ func (rp *RefreshingProvider) handleUnauthorized(retry func() error) error {
 	return retry()
 }
 
-func (rp *RefreshingProvider) ListPipelines(repo domain.Repository) ([]domain.Pipeline, error) {
-	result, err := rp.inner.ListPipelines(repo)
+// withRefresh calls fn and, if it fails with domain.ErrUnauthorized, refreshes
+// the token and calls fn once more.
+func withRefresh[T any](rp *RefreshingProvider, fn func() (T, error)) (T, error) {
+	result, err := fn()
 	if err != nil && errors.Is(err, domain.ErrUnauthorized) {
-		var retryResult []domain.Pipeline
+		var retryResult T
 		retryErr := rp.handleUnauthorized(func() error {
 			var e error
-			retryResult, e = rp.inner.ListPipelines(repo)
+			retryResult, e = fn()
 			return e
 		})
 		if retryErr != nil {
-			return nil, retryErr
+			var zero T
+			return zero, retryErr
 		}
 		return retryResult, nil
 	}
 	return result, err
 }
 
+func (rp *RefreshingProvider) ListPipelines(repo domain.Repository) ([]domain.Pipeline, error) {
+	return withRefresh(rp, func() ([]domain.Pipeline, error) {
+		return rp.inner.ListPipelines(repo)
+	})
+}
+
 func (rp *RefreshingProvider) GetPipeline(repo domain.Repository, id domain.PipelineID) (domain.Pipeline, error) {
-	result, err := rp.inner.GetPipeline(repo, id)
-	if err != nil && errors.Is(err, domain.ErrUnauthorized) {
-		var retryResult domain.Pipeline
-		retryErr := rp.handleUnauthorized(func() error {
-			var e error
-			retryResult, e = rp.inner.GetPipeline(repo, id)
-			return e
-		})
-		if retryErr != nil {
-			return domain.Pipeline{}, retryErr
-		}
-		return retryResult, nil
-	}
-	return result, err
+	return withRefresh(rp, func() (domain.Pipeline, error) {
+		return rp.inner.GetPipeline(repo, id)
+	})
 }
 
 func (rp *RefreshingProvider) GetJobLogs(repo domain.Repository, jobID domain.JobID) (string, error) {
-	result, err := rp.inner.GetJobLogs(repo, jobID)
-	if err != nil && errors.Is(err, domain.ErrUnauthorized) {
-		var retryResult string
-		retryErr := rp.handleUnauthorized(func() error {
-			var e error
-			retryResult, e = rp.inner.GetJobLogs(repo, jobID)
-			return e
-		})
-		if retryErr != nil {
-			return "", retryErr
-		}
-		return retryResult, nil
-	}
-	return result, err
+	return withRefresh(rp, func() (string, error) {
+		return rp.inner.GetJobLogs(repo, jobID)
+	})
 }
 
 func (rp *RefreshingProvider) RerunPipeline(repo domain.Repository, id domain.PipelineID) error {
-	err := rp.inner.RerunPipeline(repo, id)
-	if err != nil && errors.Is(err, domain.ErrUnauthorized) {
-		return rp.handleUnauthorized(func() error {
-			return rp.inner.RerunPipeline(repo, id)
-		})
-	}
+	_, err := withRefresh(rp, func() (struct{}, error) {
+		return struct{}{}, rp.inner.RerunPipeline(repo, id)
+	})
 	return err
 }
 
 func (rp *RefreshingProvider) CancelPipeline(repo domain.Repository, id domain.PipelineID) error {
-	err := rp.inner.CancelPipeline(repo, id)
-	if err != nil && errors.Is(err, domain.ErrUnauthorized) {
-		return rp.handleUnauthorized(func() error {
-			return rp.inner.CancelPipeline(repo, id)
-		})
+	_, err := withRefresh(rp, func() (struct{}, error) {
+		return struct{}{}, rp.inner.CancelPipeline(repo, id)
+	})
+	return err
+}
+
+// ListArtifacts forwards to the wrapped provider if it implements domain.ArtifactProvider.
+func (rp *RefreshingProvider) ListArtifacts(repo domain.Repository, id domain.PipelineID) ([]domain.Artifact, error) {
+	inner, ok := rp.inner.(domain.ArtifactProvider)
+	if !ok {
+		return nil, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() ([]domain.Artifact, error) {
+		return inner.ListArtifacts(repo, id)
+	})
+}
+
+// DownloadArtifact forwards to the wrapped provider if it implements domain.ArtifactProvider.
+// A 401 is reported before any bytes are written, so retrying into the same writer is safe.
+func (rp *RefreshingProvider) DownloadArtifact(repo domain.Repository, artifact domain.Artifact, dst io.Writer, progress func(written, total int64)) error {
+	inner, ok := rp.inner.(domain.ArtifactProvider)
+	if !ok {
+		return domain.ErrNotSupported
+	}
+	_, err := withRefresh(rp, func() (struct{}, error) {
+		return struct{}{}, inner.DownloadArtifact(repo, artifact, dst, progress)
+	})
+	return err
+}
+
+// GetTestReport forwards to the wrapped provider if it implements domain.TestReportProvider.
+func (rp *RefreshingProvider) GetTestReport(repo domain.Repository, id domain.PipelineID) (domain.TestReport, error) {
+	inner, ok := rp.inner.(domain.TestReportProvider)
+	if !ok {
+		return domain.TestReport{}, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() (domain.TestReport, error) {
+		return inner.GetTestReport(repo, id)
+	})
+}
+
+// GetPipelineVariables forwards to the wrapped provider if it implements domain.VariableProvider.
+func (rp *RefreshingProvider) GetPipelineVariables(repo domain.Repository, id domain.PipelineID) (domain.PipelineVariables, error) {
+	inner, ok := rp.inner.(domain.VariableProvider)
+	if !ok {
+		return domain.PipelineVariables{}, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() (domain.PipelineVariables, error) {
+		return inner.GetPipelineVariables(repo, id)
+	})
+}
+
+// GetJobAnnotations forwards to the wrapped provider if it implements domain.AnnotationProvider.
+func (rp *RefreshingProvider) GetJobAnnotations(repo domain.Repository, jobID domain.JobID) ([]domain.Annotation, error) {
+	inner, ok := rp.inner.(domain.AnnotationProvider)
+	if !ok {
+		return nil, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() ([]domain.Annotation, error) {
+		return inner.GetJobAnnotations(repo, jobID)
+	})
+}
+
+// ListMergeRequestPipelines forwards to the wrapped provider if it implements
+// domain.MergeRequestPipelineProvider.
+func (rp *RefreshingProvider) ListMergeRequestPipelines(repo domain.Repository, mr domain.MergeRequest) ([]domain.Pipeline, error) {
+	inner, ok := rp.inner.(domain.MergeRequestPipelineProvider)
+	if !ok {
+		return nil, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() ([]domain.Pipeline, error) {
+		return inner.ListMergeRequestPipelines(repo, mr)
+	})
+}
+
+// ListWorkflowPipelines forwards to the wrapped provider if it implements
+// domain.WorkflowPipelineProvider.
+func (rp *RefreshingProvider) ListWorkflowPipelines(repo domain.Repository, workflowID string) ([]domain.Pipeline, error) {
+	inner, ok := rp.inner.(domain.WorkflowPipelineProvider)
+	if !ok {
+		return nil, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() ([]domain.Pipeline, error) {
+		return inner.ListWorkflowPipelines(repo, workflowID)
+	})
+}
+
+// GetPipelineAttempt forwards to the wrapped provider if it implements domain.AttemptProvider.
+func (rp *RefreshingProvider) GetPipelineAttempt(repo domain.Repository, id domain.PipelineID, attempt int) (domain.Pipeline, error) {
+	inner, ok := rp.inner.(domain.AttemptProvider)
+	if !ok {
+		return domain.Pipeline{}, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() (domain.Pipeline, error) {
+		return inner.GetPipelineAttempt(repo, id, attempt)
+	})
+}
+
+// ListEnvironments forwards to the wrapped provider if it implements domain.EnvironmentProvider.
+func (rp *RefreshingProvider) ListEnvironments(repo domain.Repository) ([]domain.Environment, error) {
+	inner, ok := rp.inner.(domain.EnvironmentProvider)
+	if !ok {
+		return nil, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() ([]domain.Environment, error) {
+		return inner.ListEnvironments(repo)
+	})
+}
+
+// RateLimit forwards to the wrapped provider if it implements domain.RateLimitReporter.
+func (rp *RefreshingProvider) RateLimit() (domain.RateLimit, bool) {
+	inner, ok := rp.inner.(domain.RateLimitReporter)
+	if !ok {
+		return domain.RateLimit{}, false
+	}
+	return inner.RateLimit()
+}
+
+// ListCommitChecks forwards to the wrapped provider if it implements domain.CommitCheckProvider.
+func (rp *RefreshingProvider) ListCommitChecks(repo domain.Repository, sha string) ([]domain.CommitCheck, error) {
+	inner, ok := rp.inner.(domain.CommitCheckProvider)
+	if !ok {
+		return nil, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() ([]domain.CommitCheck, error) {
+		return inner.ListCommitChecks(repo, sha)
+	})
+}
+
+// ListRunners forwards to the wrapped provider if it implements domain.RunnerProvider.
+func (rp *RefreshingProvider) ListRunners(repo domain.Repository) ([]domain.Runner, error) {
+	inner, ok := rp.inner.(domain.RunnerProvider)
+	if !ok {
+		return nil, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() ([]domain.Runner, error) {
+		return inner.ListRunners(repo)
+	})
+}
+
+// ListSchedules forwards to the wrapped provider if it implements domain.ScheduleProvider.
+func (rp *RefreshingProvider) ListSchedules(repo domain.Repository) ([]domain.Schedule, error) {
+	inner, ok := rp.inner.(domain.ScheduleProvider)
+	if !ok {
+		return nil, domain.ErrNotSupported
+	}
+	return withRefresh(rp, func() ([]domain.Schedule, error) {
+		return inner.ListSchedules(repo)
+	})
+}
+
+// RunSchedule forwards to the wrapped provider if it implements domain.ScheduleController.
+func (rp *RefreshingProvider) RunSchedule(repo domain.Repository, scheduleID string) error {
+	inner, ok := rp.inner.(domain.ScheduleController)
+	if !ok {
+		return domain.ErrNotSupported
+	}
+	_, err := withRefresh(rp, func() (struct{}, error) {
+		return struct{}{}, inner.RunSchedule(repo, scheduleID)
+	})
+	return err
+}
+
+// SetScheduleActive forwards to the wrapped provider if it implements domain.ScheduleController.
+func (rp *RefreshingProvider) SetScheduleActive(repo domain.Repository, scheduleID string, active bool) error {
+	inner, ok := rp.inner.(domain.ScheduleController)
+	if !ok {
+		return domain.ErrNotSupported
 	}
+	_, err := withRefresh(rp, func() (struct{}, error) {
+		return struct{}{}, inner.SetScheduleActive(repo, scheduleID, active)
+	})
 	return err
 }
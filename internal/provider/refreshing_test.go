@@ -4,6 +4,7 @@ package provider_test
 import (
 	"errors"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/waabox/gitdeck/internal/domain"
@@ -243,3 +244,49 @@ func (f *failOnceRerunProvider) RerunPipeline(_ domain.Repository, _ domain.Pipe
 func (f *failOnceRerunProvider) CancelPipeline(_ domain.Repository, _ domain.PipelineID) error {
 	return nil
 }
+
+func TestRefreshingProvider_ListArtifacts_NotSupportedWhenInnerLacksCapability(t *testing.T) {
+	rp := provider.NewRefreshingProvider(&mockProvider{}, "gitlab",
+		func() (string, error) { return "", nil },
+		func(token string) {},
+	)
+
+	_, err := rp.ListArtifacts(domain.Repository{}, "1")
+	if !errors.Is(err, domain.ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got: %v", err)
+	}
+}
+
+func TestRefreshingProvider_ListArtifacts_RetriesOn401(t *testing.T) {
+	inner := &artifactProvider{firstErr: fmt.Errorf("github API error: %w", domain.ErrUnauthorized)}
+	rp := provider.NewRefreshingProvider(inner, "github",
+		func() (string, error) { return "new-token", nil },
+		func(token string) {},
+	)
+
+	artifacts, err := rp.ListArtifacts(domain.Repository{}, "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(artifacts) != 1 || inner.calls != 2 {
+		t.Errorf("expected retry to return 1 artifact after 2 calls, got %d after %d", len(artifacts), inner.calls)
+	}
+}
+
+// artifactProvider implements domain.ArtifactProvider, failing the first ListArtifacts call.
+type artifactProvider struct {
+	mockProvider
+	calls    int
+	firstErr error
+}
+
+func (a *artifactProvider) ListArtifacts(_ domain.Repository, _ domain.PipelineID) ([]domain.Artifact, error) {
+	a.calls++
+	if a.calls == 1 {
+		return nil, a.firstErr
+	}
+	return []domain.Artifact{{ID: "1", Name: "report"}}, nil
+}
+func (a *artifactProvider) DownloadArtifact(_ domain.Repository, _ domain.Artifact, _ io.Writer, _ func(written, total int64)) error {
+	return nil
+}
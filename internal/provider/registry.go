@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/git"
 )
 
 // Registry maps remote URL host patterns to PipelineProvider implementations.
@@ -13,27 +14,107 @@ type Registry struct {
 }
 
 type entry struct {
-	host     string
+	pattern  pattern
 	provider domain.PipelineProvider
 }
 
+// pattern is a parsed host pattern. host is either an exact host or, for
+// patterns such as "*.gitlab.corp", the suffix ".gitlab.corp".
+type pattern struct {
+	host     string
+	wildcard bool
+	port     string
+	path     string
+}
+
+// Match specificities, most specific first.
+const (
+	matchPath = iota
+	matchHost
+	matchWildcard
+	noMatch
+)
+
 // NewRegistry creates an empty provider registry.
 func NewRegistry() *Registry {
 	return &Registry{}
 }
 
-// Register associates a host pattern (e.g., "github.com") with a provider.
-func (r *Registry) Register(host string, p domain.PipelineProvider) {
-	r.entries = append(r.entries, entry{host: host, provider: p})
+// Register associates a host pattern with a provider. The pattern is a host
+// ("github.com"), a wildcard matching its subdomains ("*.gitlab.corp"), or a
+// host with a path prefix ("github.com/acme") to single out some remotes.
+// A port ("gitlab.corp:8443") restricts the pattern to remotes that name that
+// port; remotes without one, such as SSH remotes, match any port. An instance
+// URL can be registered as is: its scheme and port are ignored, since they are
+// those of the web interface and not of the remotes cloned from it.
+func (r *Registry) Register(hostPattern string, p domain.PipelineProvider) {
+	r.entries = append(r.entries, entry{pattern: parsePattern(hostPattern), provider: p})
 }
 
 // Detect returns the provider matching the host in the given remote URL.
+// Hosts are compared exactly, never as substrings. When several patterns
+// match, a path prefix wins over an exact host, which wins over a wildcard;
+// among equally specific patterns the one registered first wins.
 // Returns an error if no matching provider is registered.
 func (r *Registry) Detect(remoteURL string) (domain.PipelineProvider, error) {
+	remote, err := git.ParseRemote(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+	var found domain.PipelineProvider
+	best := noMatch
 	for _, e := range r.entries {
-		if strings.Contains(remoteURL, e.host) {
-			return e.provider, nil
+		if m := e.pattern.match(remote); m < best {
+			found, best = e.provider, m
 		}
 	}
-	return nil, fmt.Errorf("no provider found for remote: %s", remoteURL)
+	if found == nil {
+		return nil, fmt.Errorf("no provider found for remote: %s", remoteURL)
+	}
+	return found, nil
+}
+
+func parsePattern(s string) pattern {
+	_, rest, isURL := strings.Cut(s, "://")
+	if isURL {
+		s = rest
+	}
+	hostPort, path, _ := strings.Cut(s, "/")
+	host, port, _ := strings.Cut(hostPort, ":")
+	if isURL {
+		port = ""
+	}
+	p := pattern{
+		host: strings.ToLower(host),
+		port: port,
+		path: strings.Trim(path, "/"),
+	}
+	if strings.HasPrefix(p.host, "*.") {
+		p.host = p.host[1:]
+		p.wildcard = true
+	}
+	return p
+}
+
+// match returns how specifically the pattern matches remote, or noMatch.
+func (p pattern) match(remote git.Remote) int {
+	if p.port != "" && remote.Port != "" && p.port != remote.Port {
+		return noMatch
+	}
+	switch {
+	case p.wildcard && !strings.HasSuffix(remote.Host, p.host):
+		return noMatch
+	case !p.wildcard && remote.Host != p.host:
+		return noMatch
+	}
+	if p.path != "" {
+		if remote.Path != p.path && !strings.HasPrefix(remote.Path, p.path+"/") {
+			return noMatch
+		}
+		return matchPath
+	}
+	if p.wildcard {
+		return matchWildcard
+	}
+	return matchHost
 }
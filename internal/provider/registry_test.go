@@ -82,3 +82,107 @@ func TestRegistry_ErrorOnUnknownHost(t *testing.T) {
 		t.Fatal("expected error for unknown host, got nil")
 	}
 }
+
+func TestRegistry_MatchesExactHostOnly(t *testing.T) {
+	gh := &fakeProvider{name: "github"}
+	gl := &fakeProvider{name: "gitlab"}
+
+	reg := provider.NewRegistry()
+	reg.Register("github.com", gh)
+	reg.Register("gitlab.com", gl)
+
+	p, err := reg.Detect("https://gitlab.com/acme/github.com-mirror.git")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p != gl {
+		t.Error("expected the host, not the path, to select the provider")
+	}
+	for _, remote := range []string{
+		"https://github.com.evil.example/acme/repo.git",
+		"git@notgithub.com:acme/repo.git",
+	} {
+		if _, err := reg.Detect(remote); err == nil {
+			t.Errorf("expected no provider for %s", remote)
+		}
+	}
+}
+
+func TestRegistry_MatchesWildcardAndPort(t *testing.T) {
+	gl := &fakeProvider{name: "gitlab-corp"}
+	staging := &fakeProvider{name: "gitlab-staging"}
+
+	reg := provider.NewRegistry()
+	reg.Register("*.gitlab.corp", gl)
+	reg.Register("staging.gitlab.corp:8443", staging)
+
+	tests := []struct {
+		remote string
+		want   domain.PipelineProvider
+	}{
+		{"ssh://git@eu.gitlab.corp:2222/team/project.git", gl},
+		{"git@us.gitlab.corp:team/project.git", gl},
+		{"https://staging.gitlab.corp:8443/team/project.git", staging},
+		{"git@staging.gitlab.corp:team/project.git", staging},
+		{"https://staging.gitlab.corp:9443/team/project.git", gl},
+	}
+	for _, tt := range tests {
+		p, err := reg.Detect(tt.remote)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.remote, err)
+		}
+		if p != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.remote, tt.want.(*fakeProvider).name, p.(*fakeProvider).name)
+		}
+	}
+	if _, err := reg.Detect("https://gitlab.corp/team/project.git"); err == nil {
+		t.Error("expected the wildcard not to match the bare domain")
+	}
+}
+
+func TestRegistry_InstanceURLMatchesRemotesOnAnyPort(t *testing.T) {
+	gl := &fakeProvider{name: "gitlab-corp"}
+
+	reg := provider.NewRegistry()
+	reg.Register("https://gitlab.corp:8443", gl)
+	reg.Register("https://gitlab.example:443", gl)
+
+	for _, remote := range []string{
+		"git@gitlab.corp:group/repo.git",
+		"ssh://git@gitlab.corp:2222/group/repo.git",
+		"https://gitlab.corp:8443/group/repo.git",
+		"https://gitlab.example/group/repo.git",
+	} {
+		p, err := reg.Detect(remote)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", remote, err)
+		}
+		if p != gl {
+			t.Errorf("%s: expected %s, got %s", remote, gl.name, p.(*fakeProvider).name)
+		}
+	}
+}
+
+func TestRegistry_PathOverrideWins(t *testing.T) {
+	gh := &fakeProvider{name: "github"}
+	gl := &fakeProvider{name: "gitlab"}
+
+	reg := provider.NewRegistry()
+	reg.Register("git.corp", gh)
+	reg.Register("git.corp/mirrors", gl)
+
+	p, err := reg.Detect("https://git.corp/mirrors/project.git")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p != gl {
+		t.Error("expected the path override to win over the host")
+	}
+	p, err = reg.Detect("https://git.corp/mirrorsmith/project.git")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p != gh {
+		t.Error("expected the path prefix to match whole segments only")
+	}
+}
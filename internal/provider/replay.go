@@ -0,0 +1,182 @@
+// internal/provider/replay.go
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// ReplayProvider serves the responses recorded in a Fixture without
+// contacting any API. Responses to the same call are served in the order
+// they were recorded, and the last one is repeated once they run out, so
+// that polling replays a pipeline's progress and then settles.
+type ReplayProvider struct {
+	mu    sync.Mutex
+	calls map[string][]RecordedCall
+	next  map[string]int
+}
+
+// Ensure ReplayProvider implements PipelineProvider and every optional capability.
+var (
+	_ domain.PipelineProvider             = (*ReplayProvider)(nil)
+	_ domain.ArtifactProvider             = (*ReplayProvider)(nil)
+	_ domain.TestReportProvider           = (*ReplayProvider)(nil)
+	_ domain.VariableProvider             = (*ReplayProvider)(nil)
+	_ domain.AnnotationProvider           = (*ReplayProvider)(nil)
+	_ domain.MergeRequestPipelineProvider = (*ReplayProvider)(nil)
+	_ domain.WorkflowPipelineProvider     = (*ReplayProvider)(nil)
+	_ domain.AttemptProvider              = (*ReplayProvider)(nil)
+	_ domain.EnvironmentProvider          = (*ReplayProvider)(nil)
+	_ domain.RunnerProvider               = (*ReplayProvider)(nil)
+	_ domain.CommitCheckProvider          = (*ReplayProvider)(nil)
+	_ domain.ScheduleProvider             = (*ReplayProvider)(nil)
+	_ domain.ScheduleController           = (*ReplayProvider)(nil)
+)
+
+// NewReplayProvider creates a ReplayProvider serving the calls in fixture.
+func NewReplayProvider(fixture Fixture) *ReplayProvider {
+	calls := make(map[string][]RecordedCall)
+	for _, call := range fixture.Calls {
+		id := callID(call.Method, call.Key)
+		calls[id] = append(calls[id], call)
+	}
+	return &ReplayProvider{calls: calls, next: make(map[string]int)}
+}
+
+// callID identifies the responses to one call in a fixture.
+func callID(method, key string) string {
+	return method + " " + key
+}
+
+// nextCall returns the recorded response to serve for method and key.
+func (rp *ReplayProvider) nextCall(method, key string) (RecordedCall, bool) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	id := callID(method, key)
+	recorded := rp.calls[id]
+	if len(recorded) == 0 {
+		return RecordedCall{}, false
+	}
+	i := rp.next[id]
+	if i < len(recorded)-1 {
+		rp.next[id] = i + 1
+	}
+	return recorded[i], true
+}
+
+// replay decodes the next recorded response to method and key. Calls that
+// were never recorded fail with domain.ErrNotFound.
+func replay[T any](rp *ReplayProvider, method, key string) (T, error) {
+	var result T
+	call, ok := rp.nextCall(method, key)
+	if !ok {
+		return result, fmt.Errorf("no recorded response to %s %s: %w", method, key, domain.ErrNotFound)
+	}
+	if call.Error != nil {
+		return result, call.Error.err()
+	}
+	if len(call.Result) > 0 {
+		if err := json.Unmarshal(call.Result, &result); err != nil {
+			return result, fmt.Errorf("decoding recorded response to %s %s: %w", method, key, err)
+		}
+	}
+	return result, nil
+}
+
+// replayAction replays the outcome of an action that returns no result.
+func replayAction(rp *ReplayProvider, method, key string) error {
+	_, err := replay[struct{}](rp, method, key)
+	return err
+}
+
+func (rp *ReplayProvider) ListPipelines(_ domain.Repository) ([]domain.Pipeline, error) {
+	return replay[[]domain.Pipeline](rp, "ListPipelines", "")
+}
+
+func (rp *ReplayProvider) GetPipeline(_ domain.Repository, id domain.PipelineID) (domain.Pipeline, error) {
+	return replay[domain.Pipeline](rp, "GetPipeline", string(id))
+}
+
+func (rp *ReplayProvider) GetJobLogs(_ domain.Repository, jobID domain.JobID) (string, error) {
+	return replay[string](rp, "GetJobLogs", string(jobID))
+}
+
+func (rp *ReplayProvider) RerunPipeline(_ domain.Repository, id domain.PipelineID) error {
+	return replayAction(rp, "RerunPipeline", string(id))
+}
+
+func (rp *ReplayProvider) CancelPipeline(_ domain.Repository, id domain.PipelineID) error {
+	return replayAction(rp, "CancelPipeline", string(id))
+}
+
+func (rp *ReplayProvider) ListArtifacts(_ domain.Repository, id domain.PipelineID) ([]domain.Artifact, error) {
+	return replay[[]domain.Artifact](rp, "ListArtifacts", string(id))
+}
+
+// DownloadArtifact writes the recorded archive to dst.
+func (rp *ReplayProvider) DownloadArtifact(_ domain.Repository, artifact domain.Artifact, dst io.Writer, progress func(written, total int64)) error {
+	archive, err := replay[[]byte](rp, "DownloadArtifact", artifact.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := dst.Write(archive); err != nil {
+		return fmt.Errorf("downloading artifact: %w", err)
+	}
+	if progress != nil {
+		progress(int64(len(archive)), int64(len(archive)))
+	}
+	return nil
+}
+
+func (rp *ReplayProvider) GetTestReport(_ domain.Repository, id domain.PipelineID) (domain.TestReport, error) {
+	return replay[domain.TestReport](rp, "GetTestReport", string(id))
+}
+
+func (rp *ReplayProvider) GetPipelineVariables(_ domain.Repository, id domain.PipelineID) (domain.PipelineVariables, error) {
+	return replay[domain.PipelineVariables](rp, "GetPipelineVariables", string(id))
+}
+
+func (rp *ReplayProvider) GetJobAnnotations(_ domain.Repository, jobID domain.JobID) ([]domain.Annotation, error) {
+	return replay[[]domain.Annotation](rp, "GetJobAnnotations", string(jobID))
+}
+
+func (rp *ReplayProvider) ListMergeRequestPipelines(_ domain.Repository, mr domain.MergeRequest) ([]domain.Pipeline, error) {
+	return replay[[]domain.Pipeline](rp, "ListMergeRequestPipelines", strconv.Itoa(mr.Number))
+}
+
+func (rp *ReplayProvider) ListWorkflowPipelines(_ domain.Repository, workflowID string) ([]domain.Pipeline, error) {
+	return replay[[]domain.Pipeline](rp, "ListWorkflowPipelines", workflowID)
+}
+
+func (rp *ReplayProvider) GetPipelineAttempt(_ domain.Repository, id domain.PipelineID, attempt int) (domain.Pipeline, error) {
+	return replay[domain.Pipeline](rp, "GetPipelineAttempt", attemptKey(id, attempt))
+}
+
+func (rp *ReplayProvider) ListEnvironments(_ domain.Repository) ([]domain.Environment, error) {
+	return replay[[]domain.Environment](rp, "ListEnvironments", "")
+}
+
+func (rp *ReplayProvider) ListCommitChecks(_ domain.Repository, sha string) ([]domain.CommitCheck, error) {
+	return replay[[]domain.CommitCheck](rp, "ListCommitChecks", sha)
+}
+
+func (rp *ReplayProvider) ListRunners(_ domain.Repository) ([]domain.Runner, error) {
+	return replay[[]domain.Runner](rp, "ListRunners", "")
+}
+
+func (rp *ReplayProvider) ListSchedules(_ domain.Repository) ([]domain.Schedule, error) {
+	return replay[[]domain.Schedule](rp, "ListSchedules", "")
+}
+
+func (rp *ReplayProvider) RunSchedule(_ domain.Repository, scheduleID string) error {
+	return replayAction(rp, "RunSchedule", scheduleID)
+}
+
+func (rp *ReplayProvider) SetScheduleActive(_ domain.Repository, scheduleID string, active bool) error {
+	return replayAction(rp, "SetScheduleActive", scheduleActiveKey(scheduleID, active))
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// renderAnnotations renders job annotations as "level path:line message" rows.
+func renderAnnotations(annotations []domain.Annotation) string {
+	var sb strings.Builder
+	for _, a := range annotations {
+		location := a.Path
+		if a.StartLine > 0 {
+			location = fmt.Sprintf("%s:%d", a.Path, a.StartLine)
+		}
+		message := firstLine(a.Message)
+		if a.Title != "" {
+			message = a.Title + ": " + message
+		}
+		sb.WriteString(fmt.Sprintf("  %s %-8s %-35s %s\n",
+			annotationIcon(a.Level),
+			a.Level,
+			truncate(location, 35),
+			truncate(message, 80),
+		))
+	}
+	return sb.String()
+}
+
+func annotationIcon(l domain.AnnotationLevel) string {
+	switch l {
+	case domain.AnnotationFailure:
+		return "✗"
+	case domain.AnnotationWarning:
+		return "!"
+	default:
+		return "i"
+	}
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"errors"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// describeError renders err followed by a hint on what the user can do
+// about it, for the API errors that have a likely cause.
+func describeError(err error) string {
+	hint := errorHint(err)
+	if hint == "" {
+		return err.Error()
+	}
+	return err.Error() + "\n" + hint
+}
+
+// errorHint returns what the user can do about err, or "" if there is
+// nothing more specific to say than the error itself.
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrForbidden):
+		return "Your token lacks the scope or role needed for this. Re-authenticate with the required scopes or ask a maintainer for access."
+	case errors.Is(err, domain.ErrNotFound):
+		return "It does not exist or your token cannot see it. Check the remote URL and that your account has access to the repository."
+	case errors.Is(err, domain.ErrConflict):
+		return "Its state changed in the meantime, e.g. the pipeline already finished. Refresh with ctrl+r and try again."
+	default:
+		return ""
+	}
+}
@@ -4,20 +4,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/waabox/gitdeck/internal/auth"
 	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/logscan"
 	"github.com/waabox/gitdeck/internal/provider"
 )
 
+// maxJobScanFindings is the number of findings shown for the selected job in
+// the Jobs view.
+const maxJobScanFindings = 3
+
+// maxLogFindings is the number of findings shown in the summary above the log.
+const maxLogFindings = 5
+
 // PipelinesLoadedMsg is sent when pipelines have been fetched from the provider.
 // It is exported so that tests can inject it directly into AppModel.Update.
+// RateLimit is the provider's API quota after the request, nil if unknown.
 type PipelinesLoadedMsg struct {
 	Pipelines []domain.Pipeline
+	RateLimit *domain.RateLimit
 	Err       error
 }
 
@@ -44,6 +56,100 @@ type LogsLoadedMsg struct {
 	Err     error
 }
 
+// ArtifactsLoadedMsg is sent when the artifacts of a pipeline have been fetched.
+type ArtifactsLoadedMsg struct {
+	Artifacts []domain.Artifact
+	Err       error
+}
+
+// VariablesLoadedMsg is sent when the variables of a pipeline have been fetched.
+type VariablesLoadedMsg struct {
+	PipelineID string
+	Variables  domain.PipelineVariables
+	Err        error
+}
+
+// TestReportLoadedMsg is sent when the test report of a pipeline has been fetched.
+type TestReportLoadedMsg struct {
+	Report domain.TestReport
+	Err    error
+}
+
+// DownloadProgressMsg reports the progress of an artifact download.
+type DownloadProgressMsg struct {
+	Written int64
+	Total   int64
+	events  <-chan tea.Msg
+}
+
+// DownloadCompleteMsg is sent when an artifact download finishes.
+// Path is the file the artifact was saved to.
+type DownloadCompleteMsg struct {
+	Path string
+	Err  error
+}
+
+// SchedulesLoadedMsg is sent when the pipeline schedules of the repository have been fetched.
+type SchedulesLoadedMsg struct {
+	Schedules []domain.Schedule
+	Err       error
+}
+
+// scheduleActionMsg is sent when a schedule action (play, activate, deactivate) completes.
+type scheduleActionMsg struct {
+	status string
+	err    error
+}
+
+// ChecksLoadedMsg is sent when the checks of the selected pipeline's commit have been fetched.
+type ChecksLoadedMsg struct {
+	SHA    string
+	Checks []domain.CommitCheck
+	Err    error
+}
+
+// RunnersLoadedMsg is sent when the runners available to the repository have been fetched.
+type RunnersLoadedMsg struct {
+	Runners []domain.Runner
+	Err     error
+}
+
+// EnvironmentsLoadedMsg is sent when the environments of the repository have been fetched.
+type EnvironmentsLoadedMsg struct {
+	Environments []domain.Environment
+	Err          error
+}
+
+// AttemptsLoadedMsg is sent when the earlier attempts of a pipeline have been fetched.
+// Jobs holds the jobs of every earlier attempt.
+type AttemptsLoadedMsg struct {
+	PipelineID string
+	Jobs       []domain.Job
+	Err        error
+}
+
+// AnnotationsLoadedMsg is sent when the annotations of a job have been fetched.
+type AnnotationsLoadedMsg struct {
+	JobID       string
+	Annotations []domain.Annotation
+	Err         error
+}
+
+// JobErrorsLoadedMsg is sent when a job's log has been fetched for the errors panel.
+type JobErrorsLoadedMsg struct {
+	Content string
+	JobName string
+	Err     error
+}
+
+// JobScannedMsg is sent when the log of a failed job selected in the Jobs view
+// has been fetched, to summarize its errors there.
+type JobScannedMsg struct {
+	JobID   string
+	Content string
+	Err     error
+}
+
 // DeviceCodeMsg carries the device code response for re-authentication.
 type DeviceCodeMsg struct {
 	Code   auth.DeviceCodeResponse
@@ -57,6 +163,15 @@ type ReAuthCompleteMsg struct {
 	Err   error
 }
 
+// upstreamPipeline is the state saved when drilling from a trigger job into
+// the downstream pipeline it started, restored when navigating back.
+type upstreamPipeline struct {
+	repo     domain.Repository
+	pipeline domain.Pipeline
+	detail   JobDetailModel
+	jobs     []domain.Job
+}
+
 // viewState indicates the current navigation level.
 type viewState int
 
@@ -66,6 +181,15 @@ const (
 	viewSteps
 	viewLogs
 	viewReAuth
+	viewTimeline
+	viewArtifacts
+	viewTests
+	viewErrors
+	viewAttempts
+	viewEnvironments
+	viewSchedules
+	viewRunners
+	viewChecks
 )
 
 // AppModel is the root Bubbletea model for gitdeck.
@@ -77,17 +201,88 @@ type AppModel struct {
 	// Pipeline level
 	list             PipelineListModel
 	selectedPipeline domain.Pipeline
+	mrFilter         *domain.MergeRequest
+	workflowFilter   string
+	workflowName     string
 	// Job level
 	detail      JobDetailModel
 	selectedJob domain.Job
+	// pipelineJobs holds every try of the selected pipeline's jobs, including
+	// retried ones; detail only lists the latest try of each job.
+	pipelineJobs []domain.Job
+	// variables are the selected pipeline's variables, loaded when the jobs
+	// view's details pane is opened and shown while showVariables is set.
+	variables        domain.PipelineVariables
+	variablesLoading bool
+	variablesErr     error
+	showVariables    bool
+	// upstream holds the pipelines drilled down from through trigger jobs,
+	// innermost last; pipelineRepo is the repository of a downstream selectedPipeline.
+	upstream     []upstreamPipeline
+	pipelineRepo domain.Repository
 	// Step level
-	steps StepListModel
+	steps          StepListModel
+	annotations    []domain.Annotation
+	annotationsErr error
+	// Timeline level
+	timeline TimelineModel
+	// Attempts level
+	attempts        AttemptsModel
+	earlierAttempts []domain.Job
+	attemptsLoading bool
+	attemptsErr     error
+	// Artifacts level
+	artifacts        ArtifactListModel
+	artifactsLoading bool
+	artifactsErr     error
+	downloadPrompt   bool
+	downloadInput    string
+	downloading      bool
+	downloadWritten  int64
+	downloadTotal    int64
+	downloadStatus   string
+	// Checks level
+	checks           CheckListModel
+	checksLoading    bool
+	checksErr        error
+	checksSHA        string
+	checksReturnView viewState
+	// Runners level
+	runners        RunnerListModel
+	runnersLoading bool
+	runnersErr     error
+	// Environments level
+	environments        EnvironmentListModel
+	environmentsLoading bool
+	environmentsErr     error
+	// rateLimit is the provider's API quota as of the last pipeline refresh.
+	// listRateLimited and detailRateLimited are set while the provider is
+	// rejecting refreshes of the pipeline list and of the selected pipeline.
+	rateLimit         *domain.RateLimit
+	listRateLimited   *domain.RateLimitedError
+	detailRateLimited *domain.RateLimitedError
+	// listStale and detailStale are the transient errors that kept the last
+	// refresh of the pipeline list and of the selected pipeline from updating
+	// the data on screen, each cleared by the next successful refresh of the same.
+	listStale   error
+	detailStale error
+	// Schedules level
+	schedules        ScheduleListModel
+	schedulesLoading bool
+	schedulesErr     error
+	scheduleStatus   string
+	// Tests level
+	tests        TestReportModel
+	testsLoading bool
+	testsErr     error
 	// General state
 	loading       bool
 	err           error
 	width         int
 	height        int
 	confirmAction string
+	// actionErr is why the last rerun or cancel was refused by the API.
+	actionErr error
 	// Log viewer state
 	logMode       bool
 	logLoading    bool
@@ -95,6 +290,17 @@ type AppModel struct {
 	logOffset     int
 	logJobName    string
 	logReturnView viewState
+	logFindings   []logscan.Finding
+	logFinding    int
+	// Errors level
+	jobErrors        FindingListModel
+	jobErrorsLoading bool
+	jobErrorsErr     error
+	jobErrorsContent string
+	jobErrorsJobName string
+	// jobScans holds the errors found in the logs of the failed jobs selected
+	// in the Jobs view, by job ID. The map is replaced, never modified.
+	jobScans map[string]jobScan
 	// Re-auth state
 	reAuthProvider string
 	reAuthCode     auth.DeviceCodeResponse
@@ -103,6 +309,13 @@ type AppModel struct {
 	OnRequestCode    func(ctx context.Context, provider string) (auth.DeviceCodeResponse, error)
 	OnPollToken      func(ctx context.Context, provider string, deviceCode string, interval int) (auth.TokenResponse, error)
 	OnTokenRefreshed func(provider string, resp auth.TokenResponse)
+	// DownloadDir is the directory proposed when saving an artifact.
+	DownloadDir string
+	// LogAnalyzer extracts failures from job logs. Defaults to the built-in rules.
+	LogAnalyzer *logscan.Analyzer
+	// Warning is shown under the header for as long as the TUI runs, e.g.
+	// while TLS certificates are not verified.
+	Warning string
 }
 
 // NewAppModel creates the root application model.
@@ -113,6 +326,8 @@ func NewAppModel(repo domain.Repository, provider domain.PipelineProvider) AppMo
 		list:     NewPipelineListModel(nil),
 		detail:   NewJobDetailModel(nil),
 		loading:  true,
+
+		LogAnalyzer: logscan.NewAnalyzer(logscan.DefaultRules()),
 	}
 }
 
@@ -122,40 +337,434 @@ func (m AppModel) Init() tea.Cmd {
 }
 
 func (m AppModel) loadPipelines() tea.Cmd {
+	filter := m.mrFilter
+	workflowID := m.workflowFilter
 	return func() tea.Msg {
-		pipelines, err := m.provider.ListPipelines(m.repo)
-		return PipelinesLoadedMsg{Pipelines: pipelines, Err: err}
+		var msg PipelinesLoadedMsg
+		switch {
+		case filter != nil:
+			msg.Pipelines, msg.Err = listMergeRequestPipelines(m.provider, m.repo, *filter)
+		case workflowID != "":
+			msg.Pipelines, msg.Err = listWorkflowPipelines(m.provider, m.repo, workflowID)
+		default:
+			msg.Pipelines, msg.Err = m.provider.ListPipelines(m.repo)
+		}
+		if rr, ok := m.provider.(domain.RateLimitReporter); ok {
+			if limit, ok := rr.RateLimit(); ok {
+				msg.RateLimit = &limit
+			}
+		}
+		return msg
 	}
 }
 
+// listMergeRequestPipelines returns the pipelines of a pull or merge request.
+// Providers that cannot query them directly fall back to filtering the most
+// recent pipelines.
+func listMergeRequestPipelines(p domain.PipelineProvider, repo domain.Repository, mr domain.MergeRequest) ([]domain.Pipeline, error) {
+	if mp, ok := p.(domain.MergeRequestPipelineProvider); ok {
+		pipelines, err := mp.ListMergeRequestPipelines(repo, mr)
+		if !errors.Is(err, domain.ErrNotSupported) {
+			return pipelines, err
+		}
+	}
+	all, err := p.ListPipelines(repo)
+	if err != nil {
+		return nil, err
+	}
+	var pipelines []domain.Pipeline
+	for _, pipeline := range all {
+		if pipeline.MergeRequest != nil && pipeline.MergeRequest.Number == mr.Number {
+			pipelines = append(pipelines, pipeline)
+		}
+	}
+	return pipelines, nil
+}
+
+// listWorkflowPipelines returns the pipelines of a single workflow.
+// Providers that cannot query them directly fall back to filtering the most
+// recent pipelines.
+func listWorkflowPipelines(p domain.PipelineProvider, repo domain.Repository, workflowID string) ([]domain.Pipeline, error) {
+	if wp, ok := p.(domain.WorkflowPipelineProvider); ok {
+		pipelines, err := wp.ListWorkflowPipelines(repo, workflowID)
+		if !errors.Is(err, domain.ErrNotSupported) {
+			return pipelines, err
+		}
+	}
+	all, err := p.ListPipelines(repo)
+	if err != nil {
+		return nil, err
+	}
+	var pipelines []domain.Pipeline
+	for _, pipeline := range all {
+		if pipeline.WorkflowID == workflowID {
+			pipelines = append(pipelines, pipeline)
+		}
+	}
+	return pipelines, nil
+}
+
+// latestAttempts returns the jobs that have not been superseded by a retry.
+func latestAttempts(jobs []domain.Job) []domain.Job {
+	var latest []domain.Job
+	for _, j := range jobs {
+		if !j.Retried {
+			latest = append(latest, j)
+		}
+	}
+	return latest
+}
+
+// allAttempts returns the jobs of every attempt of the selected pipeline.
+func (m AppModel) allAttempts() []domain.Job {
+	jobs := make([]domain.Job, 0, len(m.earlierAttempts)+len(m.pipelineJobs))
+	jobs = append(jobs, m.earlierAttempts...)
+	return append(jobs, m.pipelineJobs...)
+}
+
+// selectedRepo returns the repository of the selected pipeline, which differs
+// from the observed repository for downstream multi-project pipelines.
+func (m AppModel) selectedRepo() domain.Repository {
+	if len(m.upstream) == 0 {
+		return m.repo
+	}
+	return m.pipelineRepo
+}
+
 func (m AppModel) loadPipelineDetail(id string) tea.Cmd {
 	return func() tea.Msg {
-		pipeline, err := m.provider.GetPipeline(m.repo, domain.PipelineID(id))
+		pipeline, err := m.provider.GetPipeline(m.selectedRepo(), domain.PipelineID(id))
 		return PipelineDetailMsg{Pipeline: pipeline, Err: err}
 	}
 }
 
 func (m AppModel) rerunPipeline(id string) tea.Cmd {
 	return func() tea.Msg {
-		err := m.provider.RerunPipeline(m.repo, domain.PipelineID(id))
+		err := m.provider.RerunPipeline(m.selectedRepo(), domain.PipelineID(id))
 		return actionResultMsg{action: "rerun", err: err}
 	}
 }
 
 func (m AppModel) cancelPipeline(id string) tea.Cmd {
 	return func() tea.Msg {
-		err := m.provider.CancelPipeline(m.repo, domain.PipelineID(id))
+		err := m.provider.CancelPipeline(m.selectedRepo(), domain.PipelineID(id))
 		return actionResultMsg{action: "cancel", err: err}
 	}
 }
 
+// loadEarlierAttempts fetches the jobs of every attempt of a pipeline before the given one.
+func (m AppModel) loadEarlierAttempts(id string, latest int) tea.Cmd {
+	return func() tea.Msg {
+		ap, ok := m.provider.(domain.AttemptProvider)
+		if !ok {
+			return AttemptsLoadedMsg{PipelineID: id, Err: domain.ErrNotSupported}
+		}
+		var jobs []domain.Job
+		for attempt := 1; attempt < latest; attempt++ {
+			pipeline, err := ap.GetPipelineAttempt(m.selectedRepo(), domain.PipelineID(id), attempt)
+			if err != nil {
+				return AttemptsLoadedMsg{PipelineID: id, Err: err}
+			}
+			for _, j := range pipeline.Jobs {
+				if j.Attempt == 0 {
+					j.Attempt = attempt
+				}
+				jobs = append(jobs, j)
+			}
+		}
+		return AttemptsLoadedMsg{PipelineID: id, Jobs: jobs}
+	}
+}
+
 func (m AppModel) loadJobLogs(job domain.Job) tea.Cmd {
 	return func() tea.Msg {
-		content, err := m.provider.GetJobLogs(m.repo, domain.JobID(job.ID))
+		content, err := m.provider.GetJobLogs(m.selectedRepo(), domain.JobID(job.ID))
 		return LogsLoadedMsg{Content: content, JobName: job.Name, Err: err}
 	}
 }
 
+func (m AppModel) loadAnnotations(job domain.Job) tea.Cmd {
+	return func() tea.Msg {
+		ap, ok := m.provider.(domain.AnnotationProvider)
+		if !ok {
+			return AnnotationsLoadedMsg{JobID: job.ID, Err: domain.ErrNotSupported}
+		}
+		annotations, err := ap.GetJobAnnotations(m.selectedRepo(), domain.JobID(job.ID))
+		return AnnotationsLoadedMsg{JobID: job.ID, Annotations: annotations, Err: err}
+	}
+}
+
+func (m AppModel) loadJobErrors(job domain.Job) tea.Cmd {
+	return func() tea.Msg {
+		content, err := m.provider.GetJobLogs(m.selectedRepo(), domain.JobID(job.ID))
+		return JobErrorsLoadedMsg{Content: content, JobName: job.Name, Err: err}
+	}
+}
+
+// scanSelectedJob fetches the log of the job selected in the Jobs view, if it
+// failed and has not been scanned yet, to summarize its errors.
+func (m AppModel) scanSelectedJob() (AppModel, tea.Cmd) {
+	jobs := m.detail.Jobs()
+	if len(jobs) == 0 {
+		return m, nil
+	}
+	job := jobs[m.detail.Cursor()]
+	if _, scanned := m.jobScans[job.ID]; scanned || job.Status != domain.StatusFailed || job.Downstream != nil {
+		return m, nil
+	}
+	m.jobScans = withJobScan(m.jobScans, job.ID, jobScan{loading: true})
+	return m, func() tea.Msg {
+		content, err := m.provider.GetJobLogs(m.selectedRepo(), domain.JobID(job.ID))
+		return JobScannedMsg{JobID: job.ID, Content: content, Err: err}
+	}
+}
+
+// jobScan is the outcome of scanning a job's log for errors.
+type jobScan struct {
+	findings []logscan.Finding
+	err      error
+	loading  bool
+}
+
+// withJobScan returns a copy of scans with the scan of the given job set.
+func withJobScan(scans map[string]jobScan, jobID string, scan jobScan) map[string]jobScan {
+	updated := make(map[string]jobScan, len(scans)+1)
+	for id, s := range scans {
+		updated[id] = s
+	}
+	updated[jobID] = scan
+	return updated
+}
+
+func (m AppModel) loadArtifacts(id string) tea.Cmd {
+	return func() tea.Msg {
+		ap, ok := m.provider.(domain.ArtifactProvider)
+		if !ok {
+			return ArtifactsLoadedMsg{Err: domain.ErrNotSupported}
+		}
+		artifacts, err := ap.ListArtifacts(m.selectedRepo(), domain.PipelineID(id))
+		return ArtifactsLoadedMsg{Artifacts: artifacts, Err: err}
+	}
+}
+
+func (m AppModel) loadSchedules() tea.Cmd {
+	return func() tea.Msg {
+		sp, ok := m.provider.(domain.ScheduleProvider)
+		if !ok {
+			return SchedulesLoadedMsg{Err: domain.ErrNotSupported}
+		}
+		schedules, err := sp.ListSchedules(m.repo)
+		return SchedulesLoadedMsg{Schedules: schedules, Err: err}
+	}
+}
+
+func (m AppModel) runSchedule(schedule domain.Schedule) tea.Cmd {
+	return func() tea.Msg {
+		sc, ok := m.provider.(domain.ScheduleController)
+		if !ok {
+			return scheduleActionMsg{err: domain.ErrNotSupported}
+		}
+		err := sc.RunSchedule(m.repo, schedule.ID)
+		return scheduleActionMsg{status: fmt.Sprintf("Triggered %s", schedule.Description), err: err}
+	}
+}
+
+func (m AppModel) toggleSchedule(schedule domain.Schedule) tea.Cmd {
+	return func() tea.Msg {
+		sc, ok := m.provider.(domain.ScheduleController)
+		if !ok {
+			return scheduleActionMsg{err: domain.ErrNotSupported}
+		}
+		err := sc.SetScheduleActive(m.repo, schedule.ID, !schedule.Active)
+		status := fmt.Sprintf("Activated %s", schedule.Description)
+		if schedule.Active {
+			status = fmt.Sprintf("Deactivated %s", schedule.Description)
+		}
+		return scheduleActionMsg{status: status, err: err}
+	}
+}
+
+func (m AppModel) loadChecks(sha string) tea.Cmd {
+	repo := m.selectedRepo()
+	return func() tea.Msg {
+		cp, ok := m.provider.(domain.CommitCheckProvider)
+		if !ok {
+			return ChecksLoadedMsg{SHA: sha, Err: domain.ErrNotSupported}
+		}
+		checks, err := cp.ListCommitChecks(repo, sha)
+		return ChecksLoadedMsg{SHA: sha, Checks: checks, Err: err}
+	}
+}
+
+// openChecks shows the checks of the selected pipeline's commit.
+// esc returns to returnView.
+func (m AppModel) openChecks(returnView viewState) (tea.Model, tea.Cmd) {
+	sha := m.selectedPipeline.CommitSHA
+	if sha == "" {
+		return m, nil
+	}
+	m.checks = NewCheckListModel(nil)
+	m.checksErr = nil
+	m.checksSHA = sha
+	m.checksLoading = true
+	m.checksReturnView = returnView
+	m.view = viewChecks
+	return m, m.loadChecks(sha)
+}
+
+func (m AppModel) loadRunners() tea.Cmd {
+	return func() tea.Msg {
+		rp, ok := m.provider.(domain.RunnerProvider)
+		if !ok {
+			return RunnersLoadedMsg{Err: domain.ErrNotSupported}
+		}
+		runners, err := rp.ListRunners(m.repo)
+		return RunnersLoadedMsg{Runners: runners, Err: err}
+	}
+}
+
+func (m AppModel) loadEnvironments() tea.Cmd {
+	return func() tea.Msg {
+		ep, ok := m.provider.(domain.EnvironmentProvider)
+		if !ok {
+			return EnvironmentsLoadedMsg{Err: domain.ErrNotSupported}
+		}
+		environments, err := ep.ListEnvironments(m.repo)
+		return EnvironmentsLoadedMsg{Environments: environments, Err: err}
+	}
+}
+
+func (m AppModel) loadVariables(id string) tea.Cmd {
+	return func() tea.Msg {
+		vp, ok := m.provider.(domain.VariableProvider)
+		if !ok {
+			return VariablesLoadedMsg{PipelineID: id, Err: domain.ErrNotSupported}
+		}
+		variables, err := vp.GetPipelineVariables(m.selectedRepo(), domain.PipelineID(id))
+		return VariablesLoadedMsg{PipelineID: id, Variables: variables, Err: err}
+	}
+}
+
+func (m AppModel) loadTestReport(id string) tea.Cmd {
+	return func() tea.Msg {
+		tp, ok := m.provider.(domain.TestReportProvider)
+		if !ok {
+			return TestReportLoadedMsg{Err: domain.ErrNotSupported}
+		}
+		report, err := tp.GetTestReport(m.selectedRepo(), domain.PipelineID(id))
+		return TestReportLoadedMsg{Report: report, Err: err}
+	}
+}
+
+// downloadArtifact starts downloading the artifact into dir in the background.
+// Progress and completion are delivered as messages on a channel that is
+// drained one message at a time by waitForDownload.
+func (m AppModel) downloadArtifact(artifact domain.Artifact, dir string) tea.Cmd {
+	ap, ok := m.provider.(domain.ArtifactProvider)
+	if !ok {
+		return func() tea.Msg { return DownloadCompleteMsg{Err: domain.ErrNotSupported} }
+	}
+	events := make(chan tea.Msg, 1)
+	go func() {
+		defer close(events)
+		path, err := saveArtifact(ap, m.selectedRepo(), artifact, dir, func(written, total int64) {
+			// Drop intermediate updates if the UI has not consumed the previous one.
+			select {
+			case events <- DownloadProgressMsg{Written: written, Total: total, events: events}:
+			default:
+			}
+		})
+		events <- DownloadCompleteMsg{Path: path, Err: err}
+	}()
+	return waitForDownload(events)
+}
+
+func waitForDownload(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-events
+	}
+}
+
+// saveArtifact downloads the artifact to a zip file in dir, creating dir if needed.
+// Existing files are never overwritten: the archive is downloaded to a
+// temporary file and moved to a file reserved beforehand, and both are
+// removed if the download fails.
+func saveArtifact(ap domain.ArtifactProvider, repo domain.Repository, artifact domain.Artifact, dir string, progress func(written, total int64)) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating download directory: %w", err)
+	}
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, artifact.Name)
+	path, err := reserveFile(dir, name, artifact.ID)
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(dir, "."+name+"-*.part")
+	if err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("creating file: %w", err)
+	}
+	// CreateTemp makes the file readable by its owner only.
+	err = tmp.Chmod(0644)
+	if err == nil {
+		err = ap.DownloadArtifact(repo, artifact, tmp, progress)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// reserveFile creates an empty <name>.zip in dir, or, if that file exists,
+// <name>-<id>.zip, then <name>-<id>-2.zip and so on, and returns its path.
+// Artifacts of different jobs or runs often share a name.
+func reserveFile(dir, name, id string) (string, error) {
+	for i := 0; ; i++ {
+		candidate := name + ".zip"
+		switch {
+		case i == 1:
+			candidate = name + "-" + id + ".zip"
+		case i > 1:
+			candidate = fmt.Sprintf("%s-%s-%d.zip", name, id, i)
+		}
+		path := filepath.Join(dir, candidate)
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("creating file: %w", err)
+		}
+		return path, f.Close()
+	}
+}
+
+// handleAuthExpired switches to the re-authentication view if err means the
+// provider's session expired and the TUI can authenticate again. The bool
+// reports whether it did, in which case the caller returns the model and command.
+func (m AppModel) handleAuthExpired(err error) (tea.Model, tea.Cmd, bool) {
+	var authErr *provider.AuthExpiredError
+	if !errors.As(err, &authErr) || m.OnRequestCode == nil {
+		return m, nil, false
+	}
+	m.reAuthProvider = authErr.Provider
+	m.view = viewReAuth
+	m.err = nil
+	return m, m.requestDeviceCode(), true
+}
+
 func (m AppModel) requestDeviceCode() tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
@@ -203,17 +812,31 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case PipelinesLoadedMsg:
 		m.loading = false
+		if msg.RateLimit != nil {
+			m.rateLimit = msg.RateLimit
+		}
 		if msg.Err != nil {
-			var authErr *provider.AuthExpiredError
-			if errors.As(msg.Err, &authErr) && m.OnRequestCode != nil {
-				m.reAuthProvider = authErr.Provider
-				m.view = viewReAuth
-				m.err = nil
-				return m, m.requestDeviceCode()
+			if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+				return model, cmd
+			}
+			// While rate limited, keep showing the pipelines already loaded;
+			// polling resumes once the limit resets.
+			var rateErr *domain.RateLimitedError
+			if errors.As(msg.Err, &rateErr) && len(m.list.Pipelines()) > 0 {
+				m.listRateLimited = rateErr
+				return m, nil
+			}
+			// Likewise after a network blip or gateway error: the next tick
+			// retries, so show the last good data marked as stale.
+			if domain.IsTransient(msg.Err) && len(m.list.Pipelines()) > 0 {
+				m.listStale = msg.Err
+				return m, nil
 			}
 			m.err = msg.Err
 			return m, nil
 		}
+		m.listRateLimited = nil
+		m.listStale = nil
 		if len(m.list.Pipelines()) == 0 {
 			m.list = NewPipelineListModel(msg.Pipelines)
 			if len(msg.Pipelines) > 0 {
@@ -231,17 +854,38 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case PipelineDetailMsg:
 		if msg.Err != nil {
-			var authErr *provider.AuthExpiredError
-			if errors.As(msg.Err, &authErr) && m.OnRequestCode != nil {
-				m.reAuthProvider = authErr.Provider
-				m.view = viewReAuth
-				m.err = nil
-				return m, m.requestDeviceCode()
+			if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+				return model, cmd
+			}
+			var rateErr *domain.RateLimitedError
+			if errors.As(msg.Err, &rateErr) {
+				m.detailRateLimited = rateErr
+				return m, nil
+			}
+			if domain.IsTransient(msg.Err) {
+				m.detailStale = msg.Err
+				return m, nil
 			}
 			m.err = msg.Err
 			return m, nil
 		}
-		m.detail = NewJobDetailModel(msg.Pipeline.Jobs)
+		if msg.Pipeline.ID != m.selectedPipeline.ID {
+			// A refresh of a pipeline the user has navigated away from.
+			return m, nil
+		}
+		m.detailRateLimited = nil
+		m.detailStale = nil
+		m.pipelineJobs = msg.Pipeline.Jobs
+		m.detail = NewJobDetailModel(latestAttempts(msg.Pipeline.Jobs))
+		if m.view == viewTimeline {
+			m.timeline = m.timeline.UpdateJobs(m.detail.Jobs(), time.Now())
+		}
+		if m.view == viewAttempts {
+			m.attempts = m.attempts.UpdateJobs(m.allAttempts())
+		}
+		if m.view == viewJobs {
+			return m.scanSelectedJob()
+		}
 
 	case tickMsg:
 		interval := 30 * time.Second
@@ -252,35 +896,213 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.selectedPipeline.Status == domain.StatusRunning && m.selectedPipeline.ID != "" {
 			cmds = append(cmds, m.loadPipelineDetail(m.selectedPipeline.ID))
 		}
-		return m, tea.Batch(cmds...)
+		return m, tea.Batch(cmds...)
+
+	case actionResultMsg:
+		if msg.err != nil {
+			if model, cmd, ok := m.handleAuthExpired(msg.err); ok {
+				return model, cmd
+			}
+			// A refused action leaves the pipelines as they were, so explain
+			// why next to them instead of replacing the view with the error.
+			if errors.Is(msg.err, domain.ErrForbidden) || errors.Is(msg.err, domain.ErrNotFound) || errors.Is(msg.err, domain.ErrConflict) {
+				m.actionErr = fmt.Errorf("could not %s pipeline #%s: %w", msg.action, m.selectedPipeline.ID, msg.err)
+				return m, nil
+			}
+			m.err = msg.err
+			return m, nil
+		}
+		m.loading = true
+		return m, m.loadPipelines()
+
+	case LogsLoadedMsg:
+		m.logLoading = false
+		if msg.Err != nil {
+			// Log errors are non-fatal: stay in the current view.
+			return m, nil
+		}
+		return m.openLogView(msg.Content, msg.JobName, m.view), nil
+
+	case AttemptsLoadedMsg:
+		if msg.PipelineID != m.selectedPipeline.ID {
+			return m, nil
+		}
+		m.attemptsLoading = false
+		if msg.Err != nil {
+			if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+				return model, cmd
+			}
+			// Attempt errors are non-fatal: the latest attempt is still shown.
+			m.attemptsErr = msg.Err
+			return m, nil
+		}
+		m.earlierAttempts = msg.Jobs
+		m.attempts = m.attempts.UpdateJobs(m.allAttempts())
+		return m, nil
+
+	case AnnotationsLoadedMsg:
+		if msg.JobID != m.selectedJob.ID {
+			// The user has moved on to another job.
+			return m, nil
+		}
+		if errors.Is(msg.Err, domain.ErrNotSupported) {
+			return m, nil
+		}
+		m.annotations = msg.Annotations
+		m.annotationsErr = msg.Err
+		return m, nil
+
+	case JobErrorsLoadedMsg:
+		m.jobErrorsLoading = false
+		if msg.Err != nil {
+			if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+				return model, cmd
+			}
+			m.jobErrorsErr = msg.Err
+			return m, nil
+		}
+		m.jobErrorsErr = nil
+		m.jobErrorsContent = msg.Content
+		m.jobErrorsJobName = msg.JobName
+		m.jobErrors = NewFindingListModel(m.LogAnalyzer.Analyze(msg.Content))
+		return m, nil
+
+	case JobScannedMsg:
+		if _, ok := m.jobScans[msg.JobID]; !ok {
+			// A scan of a pipeline the user has navigated away from.
+			return m, nil
+		}
+		scan := jobScan{err: msg.Err}
+		if msg.Err == nil {
+			scan.findings = m.LogAnalyzer.Analyze(msg.Content)
+		}
+		m.jobScans = withJobScan(m.jobScans, msg.JobID, scan)
+		return m, nil
+
+	case ArtifactsLoadedMsg:
+		m.artifactsLoading = false
+		if msg.Err != nil {
+			if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+				return model, cmd
+			}
+			// Artifact errors are non-fatal: show them inside the panel.
+			m.artifactsErr = msg.Err
+			return m, nil
+		}
+		m.artifactsErr = nil
+		m.artifacts = NewArtifactListModel(msg.Artifacts)
+		return m, nil
+
+	case SchedulesLoadedMsg:
+		m.schedulesLoading = false
+		if msg.Err != nil {
+			if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+				return model, cmd
+			}
+			// Schedule errors are non-fatal: show them inside the panel.
+			m.schedulesErr = msg.Err
+			return m, nil
+		}
+		m.schedulesErr = nil
+		m.schedules = m.schedules.UpdateSchedules(msg.Schedules)
+		return m, nil
+
+	case scheduleActionMsg:
+		if msg.err != nil {
+			if model, cmd, ok := m.handleAuthExpired(msg.err); ok {
+				return model, cmd
+			}
+			m.scheduleStatus = fmt.Sprintf("Schedule action failed: %v", msg.err)
+			return m, nil
+		}
+		m.scheduleStatus = msg.status
+		return m, m.loadSchedules()
+
+	case ChecksLoadedMsg:
+		if msg.SHA != m.checksSHA {
+			// Checks of a commit the user has navigated away from.
+			return m, nil
+		}
+		m.checksLoading = false
+		if msg.Err != nil {
+			if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+				return model, cmd
+			}
+			// Check errors are non-fatal: show them inside the panel.
+			m.checksErr = msg.Err
+			return m, nil
+		}
+		m.checksErr = nil
+		m.checks = NewCheckListModel(msg.Checks)
+		return m, nil
+
+	case RunnersLoadedMsg:
+		m.runnersLoading = false
+		if msg.Err != nil {
+			if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+				return model, cmd
+			}
+			// Runner errors are non-fatal: show them inside the panel.
+			m.runnersErr = msg.Err
+			return m, nil
+		}
+		m.runnersErr = nil
+		m.runners = NewRunnerListModel(msg.Runners)
+		return m, nil
+
+	case EnvironmentsLoadedMsg:
+		m.environmentsLoading = false
+		if msg.Err != nil {
+			if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+				return model, cmd
+			}
+			// Environment errors are non-fatal: show them inside the panel.
+			m.environmentsErr = msg.Err
+			return m, nil
+		}
+		m.environmentsErr = nil
+		m.environments = NewEnvironmentListModel(msg.Environments)
+		return m, nil
+
+	case VariablesLoadedMsg:
+		if msg.PipelineID != m.selectedPipeline.ID {
+			// Variables of a pipeline the user has navigated away from.
+			return m, nil
+		}
+		m.variablesLoading = false
+		if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+			return model, cmd
+		}
+		m.variables = msg.Variables
+		m.variablesErr = msg.Err
+		return m, nil
 
-	case actionResultMsg:
-		if msg.err != nil {
-			var authErr *provider.AuthExpiredError
-			if errors.As(msg.err, &authErr) && m.OnRequestCode != nil {
-				m.reAuthProvider = authErr.Provider
-				m.view = viewReAuth
-				m.err = nil
-				return m, m.requestDeviceCode()
+	case TestReportLoadedMsg:
+		m.testsLoading = false
+		if msg.Err != nil {
+			if model, cmd, ok := m.handleAuthExpired(msg.Err); ok {
+				return model, cmd
 			}
-			m.err = msg.err
+			// Test report errors are non-fatal: show them inside the panel.
+			m.testsErr = msg.Err
 			return m, nil
 		}
-		m.loading = true
-		return m, m.loadPipelines()
+		m.testsErr = nil
+		m.tests = NewTestReportModel(msg.Report)
+		return m, nil
 
-	case LogsLoadedMsg:
-		m.logLoading = false
+	case DownloadProgressMsg:
+		m.downloadWritten = msg.Written
+		m.downloadTotal = msg.Total
+		return m, waitForDownload(msg.events)
+
+	case DownloadCompleteMsg:
+		m.downloading = false
 		if msg.Err != nil {
-			// Log errors are non-fatal: stay in the current view.
+			m.downloadStatus = fmt.Sprintf("Download failed: %v", msg.Err)
 			return m, nil
 		}
-		m.logReturnView = m.view
-		m.view = viewLogs
-		m.logMode = true
-		m.logContent = msg.Content
-		m.logJobName = msg.JobName
-		m.logOffset = 0
+		m.downloadStatus = fmt.Sprintf("Saved to %s", msg.Path)
 		return m, nil
 
 	case DeviceCodeMsg:
@@ -309,6 +1131,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.reAuthProvider = ""
 		m.reAuthCode = auth.DeviceCodeResponse{}
 		m.view = viewPipelines
+		m.upstream = nil
 		m.loading = true
 		m.err = nil
 		return m, m.loadPipelines()
@@ -317,6 +1140,13 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.confirmAction != "" {
 			switch msg.String() {
 			case "y":
+				if m.confirmAction == "play" {
+					m.confirmAction = ""
+					if schedule, ok := m.schedules.SelectedSchedule(); ok {
+						return m, m.runSchedule(schedule)
+					}
+					return m, nil
+				}
 				if m.selectedPipeline.ID == "" {
 					m.confirmAction = ""
 					return m, nil
@@ -334,6 +1164,9 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 		}
+		if m.downloadPrompt {
+			return m.updateDownloadPrompt(msg)
+		}
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
@@ -350,6 +1183,24 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateSteps(msg)
 		case viewLogs:
 			return m.updateLogs(msg)
+		case viewTimeline:
+			return m.updateTimeline(msg)
+		case viewArtifacts:
+			return m.updateArtifacts(msg)
+		case viewTests:
+			return m.updateTests(msg)
+		case viewErrors:
+			return m.updateErrors(msg)
+		case viewAttempts:
+			return m.updateAttempts(msg)
+		case viewEnvironments:
+			return m.updateEnvironments(msg)
+		case viewRunners:
+			return m.updateRunners(msg)
+		case viewChecks:
+			return m.updateChecks(msg)
+		case viewSchedules:
+			return m.updateSchedules(msg)
 		case viewReAuth:
 			if msg.String() == "esc" || msg.String() == "q" || msg.String() == "ctrl+c" {
 				if m.reAuthCancel != nil {
@@ -381,13 +1232,66 @@ func (m AppModel) updatePipelines(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		if len(m.list.Pipelines()) > 0 {
 			m.selectedPipeline = m.list.SelectedPipeline()
+			m.upstream = nil
+			m.showVariables = false
+			m.jobScans = nil
 			m.view = viewJobs
 			return m, m.loadPipelineDetail(m.selectedPipeline.ID)
 		}
+	case "m":
+		// Toggle between all pipelines and the pipelines of the selected review.
+		if m.mrFilter != nil {
+			m.mrFilter = nil
+		} else if mr := m.list.SelectedPipeline().MergeRequest; mr != nil {
+			m.mrFilter = mr
+			m.workflowFilter = ""
+		} else {
+			return m, nil
+		}
+		m.list = NewPipelineListModel(nil)
+		m.loading = true
+		return m, m.loadPipelines()
+	case "S":
+		m.schedules = NewScheduleListModel(nil)
+		m.schedulesErr = nil
+		m.scheduleStatus = ""
+		m.schedulesLoading = true
+		m.view = viewSchedules
+		return m, m.loadSchedules()
+	case "c":
+		return m.openChecks(viewPipelines)
+	case "R":
+		m.runners = NewRunnerListModel(nil)
+		m.runnersErr = nil
+		m.runnersLoading = true
+		m.view = viewRunners
+		return m, m.loadRunners()
+	case "E":
+		m.environments = NewEnvironmentListModel(nil)
+		m.environmentsErr = nil
+		m.environmentsLoading = true
+		m.view = viewEnvironments
+		return m, m.loadEnvironments()
+	case "w":
+		// Toggle between all pipelines and the runs of the selected workflow.
+		if m.workflowFilter != "" {
+			m.workflowFilter = ""
+		} else if selected := m.list.SelectedPipeline(); selected.WorkflowID != "" {
+			m.workflowFilter = selected.WorkflowID
+			m.workflowName = selected.Workflow
+			m.mrFilter = nil
+		} else {
+			return m, nil
+		}
+		m.list = NewPipelineListModel(nil)
+		m.loading = true
+		return m, m.loadPipelines()
 	case "r":
 		m.confirmAction = "rerun"
+		m.actionErr = nil
 	case "x":
 		m.confirmAction = "cancel"
+		m.actionErr = nil
 	}
 	return m, nil
 }
@@ -396,14 +1300,22 @@ func (m AppModel) updateJobs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "down":
 		m.detail = m.detail.MoveDown()
+		return m.scanSelectedJob()
 	case "up":
 		m.detail = m.detail.MoveUp()
+		return m.scanSelectedJob()
 	case "enter":
 		jobs := m.detail.Jobs()
 		if len(jobs) > 0 {
+			if downstream := jobs[m.detail.Cursor()].Downstream; downstream != nil {
+				return m.openDownstream(*downstream)
+			}
 			m.selectedJob = jobs[m.detail.Cursor()]
 			m.steps = NewStepListModel(m.selectedJob.Steps)
+			m.annotations = nil
+			m.annotationsErr = nil
 			m.view = viewSteps
+			return m, m.loadAnnotations(m.selectedJob)
 		}
 	case "l":
 		if !m.logLoading {
@@ -413,16 +1325,89 @@ func (m AppModel) updateJobs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, m.loadJobLogs(jobs[m.detail.Cursor()])
 			}
 		}
+	case "t":
+		m.timeline = NewTimelineModel(m.detail.Jobs(), time.Now())
+		m.view = viewTimeline
+	case "a":
+		m.artifacts = NewArtifactListModel(nil)
+		m.artifactsErr = nil
+		m.artifactsLoading = true
+		m.downloadStatus = ""
+		m.view = viewArtifacts
+		return m, m.loadArtifacts(m.selectedPipeline.ID)
+	case "e":
+		jobs := m.detail.Jobs()
+		if len(jobs) > 0 {
+			m.jobErrors = NewFindingListModel(nil)
+			m.jobErrorsErr = nil
+			m.jobErrorsLoading = true
+			m.view = viewErrors
+			return m, m.loadJobErrors(jobs[m.detail.Cursor()])
+		}
+	case "c":
+		return m.openChecks(viewJobs)
+	case "v":
+		m.showVariables = !m.showVariables
+		if m.showVariables {
+			m.variables = domain.PipelineVariables{}
+			m.variablesErr = nil
+			m.variablesLoading = true
+			return m, m.loadVariables(m.selectedPipeline.ID)
+		}
+	case "A":
+		m.attempts = NewAttemptsModel(m.pipelineJobs)
+		m.earlierAttempts = nil
+		m.attemptsErr = nil
+		m.view = viewAttempts
+		if m.selectedPipeline.Attempt > 1 {
+			m.attemptsLoading = true
+			return m, m.loadEarlierAttempts(m.selectedPipeline.ID, m.selectedPipeline.Attempt)
+		}
+	case "T":
+		m.tests = NewTestReportModel(domain.TestReport{})
+		m.testsErr = nil
+		m.testsLoading = true
+		m.view = viewTests
+		return m, m.loadTestReport(m.selectedPipeline.ID)
 	case "esc":
+		if n := len(m.upstream); n > 0 {
+			parent := m.upstream[n-1]
+			m.upstream = m.upstream[:n-1]
+			m.pipelineRepo = parent.repo
+			m.selectedPipeline = parent.pipeline
+			m.detail = parent.detail
+			m.pipelineJobs = parent.jobs
+			m.showVariables = false
+			return m, nil
+		}
 		m.view = viewPipelines
 	case "r":
 		m.confirmAction = "rerun"
+		m.actionErr = nil
 	case "x":
 		m.confirmAction = "cancel"
+		m.actionErr = nil
 	}
 	return m, nil
 }
 
+// openDownstream drills from a trigger job into the pipeline it started.
+func (m AppModel) openDownstream(downstream domain.DownstreamPipeline) (tea.Model, tea.Cmd) {
+	m.upstream = append(m.upstream, upstreamPipeline{
+		repo:     m.selectedRepo(),
+		pipeline: m.selectedPipeline,
+		detail:   m.detail,
+		jobs:     m.pipelineJobs,
+	})
+	m.pipelineRepo = downstream.Repository
+	m.selectedPipeline = downstream.Pipeline
+	m.detail = NewJobDetailModel(nil)
+	m.pipelineJobs = nil
+	m.showVariables = false
+	m.jobScans = nil
+	return m, m.loadPipelineDetail(downstream.Pipeline.ID)
+}
+
 func (m AppModel) updateSteps(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "down":
@@ -440,6 +1425,212 @@ func (m AppModel) updateSteps(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m AppModel) updateTimeline(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "down":
+		m.timeline = m.timeline.MoveDown()
+	case "up":
+		m.timeline = m.timeline.MoveUp()
+	case "l":
+		if !m.logLoading {
+			jobs := m.detail.Jobs()
+			if len(jobs) > 0 {
+				m.logLoading = true
+				return m, m.loadJobLogs(jobs[m.timeline.Cursor()])
+			}
+		}
+	case "esc":
+		m.view = viewJobs
+	}
+	return m, nil
+}
+
+func (m AppModel) updateSchedules(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	schedule, ok := m.schedules.SelectedSchedule()
+	switch msg.String() {
+	case "down":
+		m.schedules = m.schedules.MoveDown()
+	case "up":
+		m.schedules = m.schedules.MoveUp()
+	case "p":
+		if ok {
+			m.confirmAction = "play"
+		}
+	case "a":
+		if ok {
+			return m, m.toggleSchedule(schedule)
+		}
+	case "enter":
+		// Open the schedule's last pipeline.
+		if !ok || schedule.LastPipelineID == "" {
+			return m, nil
+		}
+		m.selectedPipeline = domain.Pipeline{ID: schedule.LastPipelineID, Branch: schedule.Ref, Status: schedule.LastStatus}
+		m.upstream = nil
+		m.showVariables = false
+		m.detail = NewJobDetailModel(nil)
+		m.view = viewJobs
+		return m, m.loadPipelineDetail(schedule.LastPipelineID)
+	case "esc":
+		m.view = viewPipelines
+	}
+	return m, nil
+}
+
+func (m AppModel) updateChecks(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "down":
+		m.checks = m.checks.MoveDown()
+	case "up":
+		m.checks = m.checks.MoveUp()
+	case "esc":
+		m.view = m.checksReturnView
+	}
+	return m, nil
+}
+
+func (m AppModel) updateRunners(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "down":
+		m.runners = m.runners.MoveDown()
+	case "up":
+		m.runners = m.runners.MoveUp()
+	case "esc":
+		m.view = viewPipelines
+	}
+	return m, nil
+}
+
+func (m AppModel) updateEnvironments(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "down":
+		m.environments = m.environments.MoveDown()
+	case "up":
+		m.environments = m.environments.MoveUp()
+	case "enter":
+		// Open the pipeline that performed the environment's last deployment.
+		env, ok := m.environments.SelectedEnvironment()
+		if !ok || env.LastDeployment == nil || env.LastDeployment.PipelineID == "" {
+			return m, nil
+		}
+		d := env.LastDeployment
+		m.selectedPipeline = domain.Pipeline{ID: d.PipelineID, Branch: d.Ref, CommitSHA: d.CommitSHA, Author: d.Author}
+		for _, p := range m.list.Pipelines() {
+			if p.ID == d.PipelineID {
+				m.selectedPipeline = p
+				break
+			}
+		}
+		m.upstream = nil
+		m.showVariables = false
+		m.detail = NewJobDetailModel(nil)
+		m.view = viewJobs
+		return m, m.loadPipelineDetail(d.PipelineID)
+	case "esc":
+		m.view = viewPipelines
+	}
+	return m, nil
+}
+
+func (m AppModel) updateAttempts(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "down":
+		m.attempts = m.attempts.MoveDown()
+	case "up":
+		m.attempts = m.attempts.MoveUp()
+	case "right":
+		m.attempts = m.attempts.MoveRight()
+	case "left":
+		m.attempts = m.attempts.MoveLeft()
+	case "l":
+		if job, ok := m.attempts.SelectedJob(); ok && !m.logLoading {
+			m.logLoading = true
+			return m, m.loadJobLogs(job)
+		}
+	case "esc":
+		m.view = viewJobs
+	}
+	return m, nil
+}
+
+func (m AppModel) updateArtifacts(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "down":
+		m.artifacts = m.artifacts.MoveDown()
+	case "up":
+		m.artifacts = m.artifacts.MoveUp()
+	case "d":
+		artifact, ok := m.artifacts.SelectedArtifact()
+		if ok && !artifact.Expired && !m.downloading {
+			m.downloadPrompt = true
+			m.downloadInput = m.DownloadDir
+			if m.downloadInput == "" {
+				m.downloadInput = "."
+			}
+		}
+	case "esc":
+		m.view = viewJobs
+	}
+	return m, nil
+}
+
+func (m AppModel) updateErrors(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "down":
+		m.jobErrors = m.jobErrors.MoveDown()
+	case "up":
+		m.jobErrors = m.jobErrors.MoveUp()
+	case "enter":
+		if len(m.jobErrors.Findings()) > 0 {
+			m = m.openLogView(m.jobErrorsContent, m.jobErrorsJobName, viewErrors)
+			m = m.jumpToFinding(m.jobErrors.Cursor())
+		}
+	case "esc":
+		m.view = viewJobs
+	}
+	return m, nil
+}
+
+func (m AppModel) updateTests(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "down":
+		m.tests = m.tests.MoveDown()
+	case "up":
+		m.tests = m.tests.MoveUp()
+	case "esc":
+		m.view = viewJobs
+	}
+	return m, nil
+}
+
+// updateDownloadPrompt handles text entry for the download directory.
+func (m AppModel) updateDownloadPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.downloadPrompt = false
+		artifact, ok := m.artifacts.SelectedArtifact()
+		if !ok || m.downloadInput == "" {
+			return m, nil
+		}
+		m.downloading = true
+		m.downloadWritten = 0
+		m.downloadTotal = artifact.Size
+		m.downloadStatus = ""
+		return m, m.downloadArtifact(artifact, m.downloadInput)
+	case tea.KeyEsc:
+		m.downloadPrompt = false
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyBackspace:
+		if r := []rune(m.downloadInput); len(r) > 0 {
+			m.downloadInput = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.downloadInput += string(msg.Runes)
+	}
+	return m, nil
+}
+
 func (m AppModel) updateLogs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "down":
@@ -464,6 +1655,14 @@ func (m AppModel) updateLogs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.logOffset > maxOffset {
 			m.logOffset = maxOffset
 		}
+	case "n":
+		if len(m.logFindings) > 0 {
+			m = m.jumpToFinding((m.logFinding + 1) % len(m.logFindings))
+		}
+	case "N":
+		if len(m.logFindings) > 0 {
+			m = m.jumpToFinding((m.logFinding - 1 + len(m.logFindings)) % len(m.logFindings))
+		}
 	case "g":
 		m.logOffset = 0
 	case "G":
@@ -474,10 +1673,32 @@ func (m AppModel) updateLogs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.logMode = false
 		m.logContent = ""
 		m.logOffset = 0
+		m.logFindings = nil
 	}
 	return m, nil
 }
 
+// openLogView switches to the log viewer for the given content and analyzes
+// it for failures. esc returns to returnView.
+func (m AppModel) openLogView(content, jobName string, returnView viewState) AppModel {
+	m.logReturnView = returnView
+	m.view = viewLogs
+	m.logMode = true
+	m.logContent = content
+	m.logJobName = jobName
+	m.logOffset = 0
+	m.logFindings = m.LogAnalyzer.Analyze(content)
+	m.logFinding = -1
+	return m
+}
+
+// jumpToFinding scrolls the log so that the i-th finding is the first visible line.
+func (m AppModel) jumpToFinding(i int) AppModel {
+	m.logFinding = i
+	m.logOffset = m.logFindings[i].Line
+	return m
+}
+
 // View renders the full TUI.
 func (m AppModel) View() string {
 	if m.logLoading {
@@ -493,13 +1714,19 @@ func (m AppModel) View() string {
 		return "Loading pipelines...\n"
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %v\n\nPress 'ctrl+r' to retry or 'q' to quit.\n", m.err)
+		return fmt.Sprintf("Error: %s\n\nPress 'ctrl+r' to retry or 'q' to quit.\n", describeError(m.err))
 	}
 
 	header := fmt.Sprintf(" gitdeck | %s / ⎇ %s %s / %s\n",
-		m.repo.Name, m.selectedPipeline.Branch,
+		m.selectedRepo().Name, m.selectedPipeline.Branch,
 		shortSHA(m.selectedPipeline.CommitSHA),
 		firstLine(m.selectedPipeline.CommitMsg))
+	if mr := m.selectedPipeline.MergeRequest; mr != nil {
+		header += renderMergeRequest(*mr)
+	}
+	if m.Warning != "" {
+		header += " ⚠ " + m.Warning + "\n"
+	}
 	separator := "────────────────────────────────────────────────────────────\n"
 
 	switch m.view {
@@ -509,6 +1736,24 @@ func (m AppModel) View() string {
 		return m.renderJobsView(header, separator)
 	case viewSteps:
 		return m.renderStepsView(header, separator)
+	case viewTimeline:
+		return m.renderTimelineView(header, separator)
+	case viewArtifacts:
+		return m.renderArtifactsView(header, separator)
+	case viewTests:
+		return m.renderTestsView(header, separator)
+	case viewErrors:
+		return m.renderErrorsView(header, separator)
+	case viewAttempts:
+		return m.renderAttemptsView(header, separator)
+	case viewEnvironments:
+		return m.renderEnvironmentsView(header, separator)
+	case viewRunners:
+		return m.renderRunnersView(header, separator)
+	case viewChecks:
+		return m.renderChecksView(header, separator)
+	case viewSchedules:
+		return m.renderSchedulesView(header, separator)
 	default:
 		return header
 	}
@@ -516,9 +1761,22 @@ func (m AppModel) View() string {
 
 func (m AppModel) renderPipelinesView(header, separator string) string {
 	title := " Pipelines\n"
+	switch {
+	case m.mrFilter != nil:
+		title = fmt.Sprintf(" Pipelines for %s\n", mergeRequestLabel(*m.mrFilter))
+	case m.workflowFilter != "":
+		title = fmt.Sprintf(" Pipelines of workflow %s\n", m.workflowName)
+	}
 	listView := m.list.View()
-	statusBar := fmt.Sprintf(" #%s by %s\n", m.selectedPipeline.ID, m.selectedPipeline.Author)
-	footer := " ↑/↓: navigate   enter: open   ctrl+r: refresh   r: rerun   x: cancel   q: quit\n"
+	statusBar := fmt.Sprintf(" #%s", m.selectedPipeline.ID)
+	switch {
+	case m.selectedPipeline.Author != "":
+		statusBar += " by " + m.selectedPipeline.Author
+	case m.selectedPipeline.CommitAuthor != "":
+		statusBar += " on a commit by " + m.selectedPipeline.CommitAuthor
+	}
+	statusBar += m.renderAPIStatus() + "\n"
+	footer := " ↑/↓: navigate   enter: open   m: PR filter   w: workflow filter   c: checks   S: schedules   R: runners   E: environments   ctrl+r: refresh   r: rerun   x: cancel   q: quit\n"
 	if m.confirmAction == "rerun" {
 		footer = fmt.Sprintf(" Rerun pipeline #%s on %s? [y/N] \n",
 			m.selectedPipeline.ID, m.selectedPipeline.Branch)
@@ -527,13 +1785,59 @@ func (m AppModel) renderPipelinesView(header, separator string) string {
 		footer = fmt.Sprintf(" Cancel pipeline #%s on %s? [y/N] \n",
 			m.selectedPipeline.ID, m.selectedPipeline.Branch)
 	}
-	return header + separator + title + listView + "\n" + separator + statusBar + separator + footer
+	return header + separator + title + listView + "\n" + separator + statusBar + m.renderActionError(separator) + separator + footer
+}
+
+// renderActionError renders why the last rerun or cancel was refused.
+func (m AppModel) renderActionError(separator string) string {
+	if m.actionErr == nil {
+		return ""
+	}
+	return separator + " " + strings.ReplaceAll(describeError(m.actionErr), "\n", "\n ") + "\n"
+}
+
+// renderAPIStatus renders the remaining API quota for the status bar, until
+// when the provider is rate limiting list refreshes, and whether the list
+// shown is stale because the last refresh failed.
+func (m AppModel) renderAPIStatus() string {
+	var sb strings.Builder
+	if m.rateLimit != nil && m.rateLimit.Limit > 0 {
+		sb.WriteString(fmt.Sprintf("   API quota: %d/%d", m.rateLimit.Remaining, m.rateLimit.Limit))
+	}
+	if m.listRateLimited != nil {
+		sb.WriteString("   rate limited" + retryAt(m.listRateLimited))
+	}
+	if m.listStale != nil {
+		sb.WriteString(fmt.Sprintf("   stale: %v", m.listStale))
+	}
+	return sb.String()
+}
+
+// retryAt renders when a rate limit resets, if the provider said so.
+func retryAt(limited *domain.RateLimitedError) string {
+	if limited.RetryAt.IsZero() {
+		return ""
+	}
+	return " until " + limited.RetryAt.Format("15:04:05")
 }
 
 func (m AppModel) renderJobsView(header, separator string) string {
 	title := fmt.Sprintf(" Jobs for Pipeline #%s\n", m.selectedPipeline.ID)
-	detailView := m.detail.ViewFocused()
-	footer := " ↑/↓: navigate   enter: steps   l: logs   esc: back   r: rerun   x: cancel   q: quit\n"
+	if n := len(m.upstream); n > 0 {
+		title = fmt.Sprintf(" Jobs for Pipeline #%s (triggered by #%s)\n",
+			m.selectedPipeline.ID, m.upstream[n-1].pipeline.ID)
+	}
+	switch {
+	case m.detailRateLimited != nil:
+		title = strings.TrimSuffix(title, "\n") + " (rate limited" + retryAt(m.detailRateLimited) + ")\n"
+	case m.detailStale != nil:
+		title = strings.TrimSuffix(title, "\n") + " (stale)\n"
+	}
+	detailView := m.detail.ViewFocused() + m.renderJobScan(separator)
+	if m.showVariables {
+		detailView += "\n" + separator + m.renderVariablesPane()
+	}
+	footer := " ↑/↓: navigate   enter: steps   l: logs   e: errors   t: timeline   a: artifacts   T: tests   A: attempts   v: variables   c: checks   esc: back   r: rerun   x: cancel   q: quit\n"
 	if m.confirmAction == "rerun" {
 		footer = fmt.Sprintf(" Rerun pipeline #%s on %s? [y/N] \n",
 			m.selectedPipeline.ID, m.selectedPipeline.Branch)
@@ -542,14 +1846,238 @@ func (m AppModel) renderJobsView(header, separator string) string {
 		footer = fmt.Sprintf(" Cancel pipeline #%s on %s? [y/N] \n",
 			m.selectedPipeline.ID, m.selectedPipeline.Branch)
 	}
-	return header + separator + title + detailView + "\n" + separator + footer
+	return header + separator + title + detailView + "\n" + m.renderActionError(separator) + separator + footer
+}
+
+// renderJobScan summarizes the errors found in the log of the job selected in
+// the Jobs view, if it failed.
+func (m AppModel) renderJobScan(separator string) string {
+	jobs := m.detail.Jobs()
+	if len(jobs) == 0 {
+		return ""
+	}
+	job := jobs[m.detail.Cursor()]
+	scan, ok := m.jobScans[job.ID]
+	if !ok {
+		return ""
+	}
+	summary := "\n" + separator
+	switch {
+	case scan.loading:
+		return summary + fmt.Sprintf(" Errors in %s: scanning log...", job.Name)
+	case scan.err != nil:
+		return summary + fmt.Sprintf(" Errors in %s: could not load log: %s", job.Name, describeError(scan.err))
+	case len(scan.findings) == 0:
+		return summary + fmt.Sprintf(" Errors in %s: none found in the log", job.Name)
+	}
+	var sb strings.Builder
+	sb.WriteString(summary + fmt.Sprintf(" Errors in %s (%d)   e: show all\n", job.Name, len(scan.findings)))
+	for i, f := range scan.findings {
+		if i == maxJobScanFindings {
+			sb.WriteString(fmt.Sprintf("   ... %d more\n", len(scan.findings)-i))
+			break
+		}
+		sb.WriteString(formatFinding("   ", f))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// renderVariablesPane renders the variables of the selected pipeline, or why
+// they cannot be shown.
+func (m AppModel) renderVariablesPane() string {
+	switch {
+	case m.variablesLoading:
+		return " Variables\n  Loading variables...\n"
+	case errors.Is(m.variablesErr, domain.ErrNotSupported):
+		return " Variables\n  This provider does not report the variables of a pipeline.\n"
+	case m.variablesErr != nil:
+		return fmt.Sprintf(" Variables\n  Could not load variables: %s\n", describeError(m.variablesErr))
+	}
+	pane := fmt.Sprintf(" Variables (%d)\n", len(m.variables.Variables))
+	if m.variables.ValuesHidden != "" {
+		pane += "  Values hidden: " + m.variables.ValuesHidden + "\n"
+	}
+	return pane + renderVariables(m.variables.Variables)
 }
 
 func (m AppModel) renderStepsView(header, separator string) string {
 	title := fmt.Sprintf(" Steps for Job: %s\n", m.selectedJob.Name)
 	stepsView := m.steps.View()
 	footer := " ↑/↓: navigate   l: logs   esc: back   q: quit\n"
-	return header + separator + title + stepsView + "\n" + separator + footer
+	annotationsView := ""
+	switch {
+	case m.annotationsErr != nil:
+		annotationsView = separator + fmt.Sprintf(" Could not load annotations: %s\n", describeError(m.annotationsErr))
+	case len(m.annotations) > 0:
+		annotationsView = separator + fmt.Sprintf(" Annotations (%d)\n", len(m.annotations)) +
+			renderAnnotations(m.annotations)
+	}
+	return header + separator + title + stepsView + "\n" + annotationsView + separator + footer
+}
+
+func (m AppModel) renderTimelineView(header, separator string) string {
+	title := fmt.Sprintf(" Timeline for Pipeline #%s\n", m.selectedPipeline.ID)
+	timelineView := m.timeline.View(m.width)
+	footer := " ↑/↓: navigate   l: logs   esc: back   q: quit\n"
+	return header + separator + title + timelineView + "\n" + separator + footer
+}
+
+func (m AppModel) renderSchedulesView(header, separator string) string {
+	title := " Schedules\n"
+	var body string
+	switch {
+	case m.schedulesLoading:
+		body = "Loading schedules...\n"
+	case m.schedulesErr != nil:
+		body = fmt.Sprintf("Could not load schedules: %s\n", describeError(m.schedulesErr))
+	default:
+		body = m.schedules.View()
+	}
+	status := ""
+	if m.scheduleStatus != "" {
+		status = " " + m.scheduleStatus + "\n"
+	}
+	footer := " ↑/↓: navigate   enter: last pipeline   p: run now   a: activate/deactivate   esc: back   q: quit\n"
+	if m.confirmAction == "play" {
+		schedule, _ := m.schedules.SelectedSchedule()
+		footer = fmt.Sprintf(" Run schedule %q now? [y/N] \n", schedule.Description)
+	}
+	return header + separator + title + body + "\n" + separator + status + footer
+}
+
+func (m AppModel) renderChecksView(header, separator string) string {
+	title := fmt.Sprintf(" Checks for commit %s\n", shortSHA(m.checksSHA))
+	var body string
+	switch {
+	case m.checksLoading:
+		body = "Loading checks...\n"
+	case m.checksErr != nil:
+		body = fmt.Sprintf("Could not load checks: %s\n", describeError(m.checksErr))
+	default:
+		title = fmt.Sprintf(" Checks for commit %s (%s)\n", shortSHA(m.checksSHA), m.checks.Summary())
+		body = m.checks.View()
+		if c, ok := m.checks.SelectedCheck(); ok && c.URL != "" {
+			body += "\n " + c.URL + "\n"
+		}
+	}
+	footer := " ↑/↓: navigate   esc: back   q: quit\n"
+	return header + separator + title + body + "\n" + separator + footer
+}
+
+func (m AppModel) renderRunnersView(header, separator string) string {
+	title := " Runners\n"
+	var body string
+	switch {
+	case m.runnersLoading:
+		body = "Loading runners...\n"
+	case m.runnersErr != nil:
+		body = fmt.Sprintf("Could not load runners: %s\n", describeError(m.runnersErr))
+	default:
+		title = fmt.Sprintf(" Runners (%d online)\n", m.runners.Online())
+		body = m.runners.View()
+	}
+	footer := " ↑/↓: navigate   esc: back   q: quit\n"
+	return header + separator + title + body + "\n" + separator + footer
+}
+
+func (m AppModel) renderEnvironmentsView(header, separator string) string {
+	title := " Environments\n"
+	var body string
+	switch {
+	case m.environmentsLoading:
+		body = "Loading environments...\n"
+	case m.environmentsErr != nil:
+		body = fmt.Sprintf("Could not load environments: %s\n", describeError(m.environmentsErr))
+	default:
+		body = m.environments.View()
+	}
+	footer := " ↑/↓: navigate   enter: open deploying pipeline   esc: back   q: quit\n"
+	return header + separator + title + body + "\n" + separator + footer
+}
+
+func (m AppModel) renderAttemptsView(header, separator string) string {
+	title := fmt.Sprintf(" Attempts for Pipeline #%s\n", m.selectedPipeline.ID)
+	body := m.attempts.View()
+	switch {
+	case m.attemptsLoading:
+		body += "\nLoading earlier attempts...\n"
+	case m.attemptsErr != nil && !errors.Is(m.attemptsErr, domain.ErrNotSupported):
+		body += fmt.Sprintf("\nCould not load earlier attempts: %s\n", describeError(m.attemptsErr))
+	}
+	footer := " ↑/↓: job   ←/→: attempt   l: logs   esc: back   q: quit\n"
+	return header + separator + title + body + "\n" + separator + footer
+}
+
+func (m AppModel) renderArtifactsView(header, separator string) string {
+	title := fmt.Sprintf(" Artifacts for Pipeline #%s\n", m.selectedPipeline.ID)
+	var body string
+	switch {
+	case m.artifactsLoading:
+		body = "Loading artifacts...\n"
+	case m.artifactsErr != nil:
+		body = fmt.Sprintf("Could not load artifacts: %s\n", describeError(m.artifactsErr))
+	default:
+		body = m.artifacts.View()
+	}
+	status := ""
+	switch {
+	case m.downloading:
+		status = " " + formatProgress(m.downloadWritten, m.downloadTotal) + "\n"
+	case m.downloadStatus != "":
+		status = " " + m.downloadStatus + "\n"
+	}
+	footer := " ↑/↓: navigate   d: download   esc: back   q: quit\n"
+	if m.downloadPrompt {
+		footer = fmt.Sprintf(" Save to directory: %s█   enter: download   esc: cancel\n", m.downloadInput)
+	}
+	return header + separator + title + body + "\n" + separator + status + footer
+}
+
+func (m AppModel) renderErrorsView(header, separator string) string {
+	title := fmt.Sprintf(" Errors for Job: %s\n", m.jobErrorsJobName)
+	var body string
+	switch {
+	case m.jobErrorsLoading:
+		title = " Errors\n"
+		body = "Analyzing job log...\n"
+	case m.jobErrorsErr != nil:
+		body = fmt.Sprintf("Could not load job log: %s\n", describeError(m.jobErrorsErr))
+	default:
+		body = m.jobErrors.View()
+	}
+	footer := " ↑/↓: navigate   enter: jump to line   esc: back   q: quit\n"
+	return header + separator + title + body + "\n" + separator + footer
+}
+
+func (m AppModel) renderTestsView(header, separator string) string {
+	title := fmt.Sprintf(" Tests for Pipeline #%s\n", m.selectedPipeline.ID)
+	var body string
+	switch {
+	case m.testsLoading:
+		body = "Loading test report...\n"
+	case m.testsErr != nil:
+		body = fmt.Sprintf("Could not load test report: %s\n", describeError(m.testsErr))
+	default:
+		body = m.tests.View()
+	}
+	footer := " ↑/↓: navigate   esc: back   q: quit\n"
+	return header + separator + title + body + "\n" + separator + footer
+}
+
+// formatProgress renders a download progress bar, e.g. "[#####     ] 50% 1.0 MiB / 2.0 MiB".
+func formatProgress(written, total int64) string {
+	if total <= 0 {
+		return fmt.Sprintf("Downloading... %s", formatBytes(written))
+	}
+	const width = 20
+	pct := float64(written) / float64(total)
+	if pct > 1 {
+		pct = 1
+	}
+	filled := int(pct * width)
+	return fmt.Sprintf("Downloading [%s%s] %3d%% %s / %s",
+		strings.Repeat("#", filled), strings.Repeat(" ", width-filled),
+		int(pct*100), formatBytes(written), formatBytes(total))
 }
 
 func (m AppModel) renderReAuthView() string {
@@ -581,6 +2109,22 @@ func Run(repo domain.Repository, provider domain.PipelineProvider) {
 	}
 }
 
+// renderMergeRequest renders the header line describing a pipeline's review,
+// e.g. " PR #42 feature → main: Add login [draft]".
+func renderMergeRequest(mr domain.MergeRequest) string {
+	line := " " + mergeRequestLabel(mr)
+	if mr.SourceBranch != "" && mr.TargetBranch != "" {
+		line += fmt.Sprintf(" %s → %s", mr.SourceBranch, mr.TargetBranch)
+	}
+	if mr.Title != "" {
+		line += ": " + mr.Title
+	}
+	if mr.Draft {
+		line += " [draft]"
+	}
+	return line + "\n"
+}
+
 func firstLine(s string) string {
 	if i := strings.IndexByte(s, '\n'); i >= 0 {
 		return s[:i]
@@ -597,19 +2141,61 @@ func shortSHA(sha string) string {
 
 // visibleLogLines returns the number of log lines visible in the current terminal height.
 func (m AppModel) visibleLogLines() int {
-	lines := m.height - 4 // account for header, separator, and footer
+	lines := m.height - 4 - m.logSummaryLines() // account for header, separator, footer and errors summary
 	if lines < 10 {
 		return 10
 	}
 	return lines
 }
 
+// logSummaryLines returns the height of the errors summary shown above the log.
+func (m AppModel) logSummaryLines() int {
+	if len(m.logFindings) == 0 {
+		return 0
+	}
+	n := len(m.logFindings)
+	if n > maxLogFindings {
+		n = maxLogFindings
+	}
+	return n + 2 // title and separator
+}
+
+// renderLogSummary renders up to maxLogFindings findings, scrolled so that the
+// current finding is always visible.
+func (m AppModel) renderLogSummary(separator string) string {
+	if len(m.logFindings) == 0 {
+		return ""
+	}
+	start := 0
+	if m.logFinding >= maxLogFindings {
+		start = m.logFinding - maxLogFindings + 1
+	}
+	end := start + maxLogFindings
+	if end > len(m.logFindings) {
+		end = len(m.logFindings)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(" Errors (%d)\n", len(m.logFindings)))
+	for i := start; i < end; i++ {
+		prefix := "  "
+		if i == m.logFinding {
+			prefix = "> "
+		}
+		sb.WriteString(formatFinding(prefix, m.logFindings[i]))
+	}
+	sb.WriteString(separator)
+	return sb.String()
+}
+
 // renderLogView renders the fullscreen log viewer.
 func (m AppModel) renderLogView() string {
 	header := fmt.Sprintf(" gitdeck  %s/%s  [logs] %s\n",
-		m.repo.Owner, m.repo.Name, m.logJobName)
+		m.selectedRepo().Owner, m.selectedRepo().Name, m.logJobName)
 	separator := "────────────────────────────────────────────────────────────\n"
 	footer := " ↑/↓: scroll   PgUp/PgDn: page   g/G: top/bottom   esc: back\n"
+	if len(m.logFindings) > 0 {
+		footer = " ↑/↓: scroll   PgUp/PgDn: page   g/G: top/bottom   n/N: next/prev error   esc: back\n"
+	}
 
 	lines := strings.Split(m.logContent, "\n")
 	visibleCount := m.visibleLogLines()
@@ -627,5 +2213,5 @@ func (m AppModel) renderLogView() string {
 	}
 
 	body := strings.Join(lines[start:end], "\n")
-	return header + separator + body + "\n" + separator + footer
+	return header + separator + m.renderLogSummary(separator) + body + "\n" + separator + footer
 }
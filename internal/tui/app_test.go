@@ -2,8 +2,13 @@ package tui_test
 
 import (
 	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/waabox/gitdeck/internal/auth"
@@ -17,6 +22,7 @@ type fakeProvider struct {
 	pipelines    []domain.Pipeline
 	rerunCalled  bool
 	cancelCalled bool
+	cancelErr    error
 }
 
 func (f *fakeProvider) ListPipelines(_ domain.Repository) ([]domain.Pipeline, error) {
@@ -34,7 +40,7 @@ func (f *fakeProvider) RerunPipeline(_ domain.Repository, _ domain.PipelineID) e
 }
 func (f *fakeProvider) CancelPipeline(_ domain.Repository, _ domain.PipelineID) error {
 	f.cancelCalled = true
-	return nil
+	return f.cancelErr
 }
 
 func TestApp_RerunKey_ShowsConfirmPrompt(t *testing.T) {
@@ -364,3 +370,702 @@ func TestApp_EscFromReAuth_ReturnsToErrorView(t *testing.T) {
 		t.Errorf("expected retry hint, got:\n%s", view)
 	}
 }
+
+func TestApp_TimelineKeyFromJobsShowsTimeline(t *testing.T) {
+	pipelines := []domain.Pipeline{
+		{ID: "1001", Branch: "main", Status: domain.StatusSuccess},
+	}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, _ := m1.(tui.AppModel).Update(tui.PipelineDetailMsg{
+		Pipeline: domain.Pipeline{
+			ID: "1001", Branch: "main",
+			Jobs: []domain.Job{{ID: "j1", Name: "build", Status: domain.StatusSuccess}},
+		},
+	})
+	m3, _ := m2.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	view := m3.(tui.AppModel).View()
+	if !strings.Contains(view, "Timeline for Pipeline #1001") {
+		t.Errorf("expected timeline view header, got:\n%s", view)
+	}
+
+	m4, _ := m3.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !strings.Contains(m4.(tui.AppModel).View(), "Jobs for Pipeline") {
+		t.Errorf("expected jobs view after esc from timeline, got:\n%s", m4.(tui.AppModel).View())
+	}
+}
+
+func TestApp_ArtifactsKey_UnsupportedProviderShowsMessage(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusSuccess}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, cmd := m1.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if cmd == nil {
+		t.Fatal("expected a command to load artifacts")
+	}
+	m3, _ := m2.(tui.AppModel).Update(cmd())
+	view := m3.(tui.AppModel).View()
+	if !strings.Contains(view, "Artifacts for Pipeline #1001") {
+		t.Errorf("expected artifacts view header, got:\n%s", view)
+	}
+	if !strings.Contains(view, "not supported") {
+		t.Errorf("expected not supported message, got:\n%s", view)
+	}
+}
+
+func TestApp_ArtifactDownloadPrompt_EditsDirectoryAndShowsProgress(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusSuccess}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+	m.DownloadDir = "/tmp/out"
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, _ := m1.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m3, _ := m2.(tui.AppModel).Update(tui.ArtifactsLoadedMsg{
+		Artifacts: []domain.Artifact{{ID: "1", Name: "report", Size: 100}},
+	})
+	m4, _ := m3.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m5, _ := m4.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/q")})
+	view := m5.(tui.AppModel).View()
+	if !strings.Contains(view, "Save to directory: /tmp/out/q") {
+		t.Errorf("expected editable directory prompt, got:\n%s", view)
+	}
+
+	m5, _ = m5.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m6, _ := m5.(tui.AppModel).Update(tui.DownloadProgressMsg{Written: 50, Total: 100})
+	if !strings.Contains(m6.(tui.AppModel).View(), "50%") {
+		t.Errorf("expected progress in view, got:\n%s", m6.(tui.AppModel).View())
+	}
+	m7, _ := m6.(tui.AppModel).Update(tui.DownloadCompleteMsg{Path: "/tmp/out/q/report.zip"})
+	if !strings.Contains(m7.(tui.AppModel).View(), "Saved to /tmp/out/q/report.zip") {
+		t.Errorf("expected completion message, got:\n%s", m7.(tui.AppModel).View())
+	}
+}
+
+// artifactProvider serves artifact archives for TUI download tests.
+type artifactProvider struct {
+	*fakeProvider
+	content string
+	err     error
+}
+
+func (p *artifactProvider) ListArtifacts(_ domain.Repository, _ domain.PipelineID) ([]domain.Artifact, error) {
+	return nil, nil
+}
+
+func (p *artifactProvider) DownloadArtifact(_ domain.Repository, _ domain.Artifact, dst io.Writer, _ func(written, total int64)) error {
+	if _, err := io.WriteString(dst, p.content); err != nil {
+		return err
+	}
+	return p.err
+}
+
+// download downloads the selected artifact into the prompted directory and
+// returns the model once the download completes.
+func download(t *testing.T, m tea.Model) (tea.Model, tui.DownloadCompleteMsg) {
+	t.Helper()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for {
+		msg := cmd()
+		m, cmd = m.Update(msg)
+		if done, ok := msg.(tui.DownloadCompleteMsg); ok {
+			return m, done
+		}
+	}
+}
+
+func TestApp_ArtifactDownloadKeepsExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusSuccess}}
+	provider := &artifactProvider{fakeProvider: &fakeProvider{pipelines: pipelines}, content: "first"}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+	m.DownloadDir = dir
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, _ := m1.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	// Jobs of a matrix build upload artifacts of the same name.
+	m3, _ := m2.(tui.AppModel).Update(tui.ArtifactsLoadedMsg{
+		Artifacts: []domain.Artifact{{ID: "301", Name: "report"}, {ID: "302", Name: "report"}},
+	})
+
+	m4, first := download(t, m3)
+	m5, _ := m4.Update(tea.KeyMsg{Type: tea.KeyDown})
+	provider.content = "second"
+	m6, second := download(t, m5)
+	if first.Err != nil || second.Err != nil {
+		t.Fatalf("unexpected errors: %v, %v", first.Err, second.Err)
+	}
+	if first.Path != filepath.Join(dir, "report.zip") || second.Path != filepath.Join(dir, "report-302.zip") {
+		t.Errorf("expected distinct files, got %s and %s", first.Path, second.Path)
+	}
+
+	provider.content, provider.err = "partial", errors.New("connection reset")
+	_, failed := download(t, m6)
+	if failed.Err == nil {
+		t.Fatal("expected the download to fail")
+	}
+	for path, want := range map[string]string{first.Path: "first", second.Path: "second"} {
+		if got, err := os.ReadFile(path); err != nil || string(got) != want {
+			t.Errorf("expected %s to keep %q, got %q (%v)", path, want, got, err)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("expected the failed download to leave no files behind, got %v", entries)
+	}
+}
+
+func TestApp_TestsKeyFromJobsShowsFailingTests(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusFailed}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, _ := m1.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	m3, _ := m2.(tui.AppModel).Update(tui.TestReportLoadedMsg{Report: domain.TestReport{Cases: []domain.TestCase{
+		{Name: "TestLogin", Status: domain.TestFailed, Message: "expected 200"},
+	}}})
+	view := m3.(tui.AppModel).View()
+	if !strings.Contains(view, "Tests for Pipeline #1001") {
+		t.Errorf("expected tests view header, got:\n%s", view)
+	}
+	if !strings.Contains(view, "TestLogin") {
+		t.Errorf("expected failing test in view, got:\n%s", view)
+	}
+}
+
+func TestApp_ErrorsKeyFromJobs_EnterJumpsIntoLog(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusFailed}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, _ := m1.(tui.AppModel).Update(tui.PipelineDetailMsg{
+		Pipeline: domain.Pipeline{ID: "1001", Jobs: []domain.Job{{ID: "j1", Name: "test", Status: domain.StatusFailed}}},
+	})
+	m3, _ := m2.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m4, _ := m3.(tui.AppModel).Update(tui.JobErrorsLoadedMsg{
+		Content: "ok\npanic: boom\nexit status 2", JobName: "test",
+	})
+	view := m4.(tui.AppModel).View()
+	if !strings.Contains(view, "Errors for Job: test") || !strings.Contains(view, "panic: boom") {
+		t.Errorf("expected errors panel with findings, got:\n%s", view)
+	}
+
+	m5, _ := m4.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view = m5.(tui.AppModel).View()
+	if !strings.Contains(view, "[logs] test") {
+		t.Errorf("expected log view after enter, got:\n%s", view)
+	}
+	m6, _ := m5.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !strings.Contains(m6.(tui.AppModel).View(), "Errors for Job: test") {
+		t.Errorf("expected esc from log to return to errors panel, got:\n%s", m6.(tui.AppModel).View())
+	}
+}
+
+func TestApp_JobsViewSummarizesErrorsOfSelectedFailedJob(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusFailed}}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, &fakeProvider{pipelines: pipelines})
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, cmd := m1.(tui.AppModel).Update(tui.PipelineDetailMsg{
+		Pipeline: domain.Pipeline{ID: "1001", Jobs: []domain.Job{
+			{ID: "j1", Name: "test", Status: domain.StatusFailed},
+			{ID: "j2", Name: "lint", Status: domain.StatusSuccess},
+		}},
+	})
+	if cmd == nil {
+		t.Fatal("expected the log of the failed job to be scanned")
+	}
+	if _, ok := cmd().(tui.JobScannedMsg); !ok {
+		t.Fatal("expected a JobScannedMsg")
+	}
+	if view := m2.(tui.AppModel).View(); !strings.Contains(view, "Errors in test: scanning log...") {
+		t.Errorf("expected the scan in progress, got:\n%s", view)
+	}
+
+	m3, _ := m2.(tui.AppModel).Update(tui.JobScannedMsg{JobID: "j1", Content: "ok\npanic: boom\nexit status 2"})
+	view := m3.(tui.AppModel).View()
+	if !strings.Contains(view, "Errors in test (2)") || !strings.Contains(view, "panic: boom") {
+		t.Errorf("expected the errors of the failed job, got:\n%s", view)
+	}
+
+	m4, cmd := m3.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyDown})
+	if cmd != nil {
+		t.Error("expected no scan of a job that did not fail")
+	}
+	if view := m4.(tui.AppModel).View(); strings.Contains(view, "Errors in") {
+		t.Errorf("expected no errors summary for a job that did not fail, got:\n%s", view)
+	}
+}
+
+func TestApp_StepsViewShowsJobAnnotations(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusFailed}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, _ := m1.(tui.AppModel).Update(tui.PipelineDetailMsg{
+		Pipeline: domain.Pipeline{ID: "1001", Jobs: []domain.Job{{ID: "j1", Name: "lint", Steps: []domain.Step{{Name: "golangci-lint"}}}}},
+	})
+	m3, _ := m2.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m4, _ := m3.(tui.AppModel).Update(tui.AnnotationsLoadedMsg{
+		JobID: "j1",
+		Annotations: []domain.Annotation{{
+			Path: "main.go", StartLine: 7, Level: domain.AnnotationWarning, Message: "unused variable x",
+		}},
+	})
+	view := m4.(tui.AppModel).View()
+	for _, want := range []string{"Annotations (1)", "warning", "main.go:7", "unused variable x"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in steps view, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestApp_MergeRequestFilter_ShowsOnlyPipelinesOfThePullRequest(t *testing.T) {
+	mr := &domain.MergeRequest{Number: 42, Title: "Add login page", SourceBranch: "feat/login", TargetBranch: "main"}
+	pipelines := []domain.Pipeline{
+		{ID: "1003", Branch: "feat/login", MergeRequest: mr},
+		{ID: "1002", Branch: "main"},
+		{ID: "1001", Branch: "feat/login", MergeRequest: mr},
+	}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+
+	view := m0.(tui.AppModel).View()
+	if !strings.Contains(view, "PR #42 feat/login → main: Add login page") {
+		t.Errorf("expected pull request in header, got:\n%s", view)
+	}
+
+	m1, cmd := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	if cmd == nil {
+		t.Fatal("expected pipelines to be reloaded")
+	}
+	m2, _ := m1.(tui.AppModel).Update(cmd())
+	view = m2.(tui.AppModel).View()
+	if !strings.Contains(view, "Pipelines for PR #42") {
+		t.Errorf("expected filtered title, got:\n%s", view)
+	}
+	if strings.Contains(view, "#1002") || !strings.Contains(view, "#1001") {
+		t.Errorf("expected only pipelines of PR #42, got:\n%s", view)
+	}
+
+	m3, cmd := m2.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m4, _ := m3.(tui.AppModel).Update(cmd())
+	if !strings.Contains(m4.(tui.AppModel).View(), "#1002") {
+		t.Errorf("expected filter to be cleared, got:\n%s", m4.(tui.AppModel).View())
+	}
+}
+
+func TestApp_EnterOnTriggerJobDrillsIntoDownstreamPipeline(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusSuccess}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, _ := m1.(tui.AppModel).Update(tui.PipelineDetailMsg{
+		Pipeline: domain.Pipeline{ID: "1001", Jobs: []domain.Job{{
+			ID: "j1", Name: "deploy",
+			Downstream: &domain.DownstreamPipeline{
+				Repository: domain.Repository{Owner: "ops", Name: "infra"},
+				Pipeline:   domain.Pipeline{ID: "2001", Branch: "main"},
+			},
+		}}},
+	})
+	if !strings.Contains(m2.(tui.AppModel).View(), "↳ pipeline #2001") {
+		t.Errorf("expected downstream marker on trigger job, got:\n%s", m2.(tui.AppModel).View())
+	}
+
+	m3, cmd := m2.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected downstream pipeline to be loaded")
+	}
+	m4, _ := m3.(tui.AppModel).Update(tui.PipelineDetailMsg{
+		Pipeline: domain.Pipeline{ID: "2001", Jobs: []domain.Job{{ID: "j9", Name: "terraform"}}},
+	})
+	view := m4.(tui.AppModel).View()
+	if !strings.Contains(view, "Jobs for Pipeline #2001 (triggered by #1001)") || !strings.Contains(view, "terraform") {
+		t.Errorf("expected downstream jobs, got:\n%s", view)
+	}
+	if !strings.Contains(view, "infra") {
+		t.Errorf("expected downstream repository in header, got:\n%s", view)
+	}
+
+	m5, _ := m4.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEsc})
+	view = m5.(tui.AppModel).View()
+	if !strings.Contains(view, "Jobs for Pipeline #1001") || !strings.Contains(view, "deploy") {
+		t.Errorf("expected esc to return to the upstream pipeline, got:\n%s", view)
+	}
+}
+
+func TestApp_WorkflowFilter_ShowsOnlyRunsOfTheWorkflow(t *testing.T) {
+	pipelines := []domain.Pipeline{
+		{ID: "1003", Branch: "main", Workflow: "CI", WorkflowID: "1"},
+		{ID: "1002", Branch: "main", Workflow: "Deploy", WorkflowID: "2"},
+		{ID: "1001", Branch: "main", Workflow: "CI", WorkflowID: "1"},
+	}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+
+	m1, cmd := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if cmd == nil {
+		t.Fatal("expected pipelines to be reloaded")
+	}
+	m2, _ := m1.(tui.AppModel).Update(cmd())
+	view := m2.(tui.AppModel).View()
+	if !strings.Contains(view, "Pipelines of workflow CI") {
+		t.Errorf("expected filtered title, got:\n%s", view)
+	}
+	if strings.Contains(view, "#1002") || !strings.Contains(view, "#1001") {
+		t.Errorf("expected only runs of workflow CI, got:\n%s", view)
+	}
+}
+
+func TestApp_AttemptsKey_ComparesRetriedJobs(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusSuccess}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, _ := m1.(tui.AppModel).Update(tui.PipelineDetailMsg{
+		Pipeline: domain.Pipeline{ID: "1001", Jobs: []domain.Job{
+			{ID: "j2", Name: "flaky-test", Attempt: 2, Status: domain.StatusSuccess},
+			{ID: "j1", Name: "flaky-test", Attempt: 1, Status: domain.StatusFailed, Retried: true},
+		}},
+	})
+	view := m2.(tui.AppModel).View()
+	if strings.Count(view, "flaky-test") != 1 || !strings.Contains(view, "(attempt 2)") {
+		t.Errorf("expected only the latest attempt in jobs view, got:\n%s", view)
+	}
+
+	m3, _ := m2.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	view = m3.(tui.AppModel).View()
+	if !strings.Contains(view, "Attempts for Pipeline #1001") {
+		t.Errorf("expected attempts view, got:\n%s", view)
+	}
+	if !strings.Contains(view, "✗") || !strings.Contains(view, "✓") {
+		t.Errorf("expected both attempt outcomes, got:\n%s", view)
+	}
+}
+
+func TestApp_EnvironmentsView_EnterOpensDeployingPipeline(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusSuccess}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, cmd := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	if msg := cmd(); msg.(tui.EnvironmentsLoadedMsg).Err == nil {
+		t.Error("expected unsupported error from a provider without environments")
+	}
+	m2, _ := m1.(tui.AppModel).Update(tui.EnvironmentsLoadedMsg{Environments: []domain.Environment{{
+		Name:           "production",
+		LastDeployment: &domain.Deployment{Ref: "v2.0.0", Status: domain.StatusSuccess, PipelineID: "990"},
+	}}})
+	if !strings.Contains(m2.(tui.AppModel).View(), "production") {
+		t.Errorf("expected environment in view, got:\n%s", m2.(tui.AppModel).View())
+	}
+
+	m3, cmd := m2.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected the deploying pipeline to be loaded")
+	}
+	if !strings.Contains(m3.(tui.AppModel).View(), "Jobs for Pipeline #990") {
+		t.Errorf("expected jobs of pipeline #990, got:\n%s", m3.(tui.AppModel).View())
+	}
+}
+
+func TestApp_SchedulesView_ConfirmsBeforeRunning(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusSuccess}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, cmd := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	if msg := cmd(); msg.(tui.SchedulesLoadedMsg).Err == nil {
+		t.Error("expected unsupported error from a provider without schedules")
+	}
+	m2, _ := m1.(tui.AppModel).Update(tui.SchedulesLoadedMsg{Schedules: []domain.Schedule{{
+		ID: "13", Description: "nightly", Ref: "main", Cron: "0 3 * * *", Active: true,
+	}}})
+	if !strings.Contains(m2.(tui.AppModel).View(), "nightly") {
+		t.Errorf("expected schedule in view, got:\n%s", m2.(tui.AppModel).View())
+	}
+
+	m3, _ := m2.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if !strings.Contains(m3.(tui.AppModel).View(), `Run schedule "nightly" now? [y/N]`) {
+		t.Errorf("expected confirmation prompt, got:\n%s", m3.(tui.AppModel).View())
+	}
+	_, cmd = m3.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("expected the schedule to be run after confirming")
+	}
+}
+
+func TestApp_RunnersView_ShowsOnlineCount(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusPending}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, cmd := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if msg := cmd(); msg.(tui.RunnersLoadedMsg).Err == nil {
+		t.Error("expected unsupported error from a provider without runners")
+	}
+	m2, _ := m1.(tui.AppModel).Update(tui.RunnersLoadedMsg{Runners: []domain.Runner{
+		{Name: "build-box", Online: true}, {Name: "old-box"},
+	}})
+	view := m2.(tui.AppModel).View()
+	if !strings.Contains(view, "Runners (1 online)") || !strings.Contains(view, "old-box") {
+		t.Errorf("expected runners view, got:\n%s", view)
+	}
+
+	m3, _ := m2.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if strings.Contains(m3.(tui.AppModel).View(), "Runners (") {
+		t.Error("expected esc to go back to pipelines")
+	}
+}
+
+func TestApp_JobsView_VariablesPaneHidesMaskedValues(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusSuccess}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, cmd := m1.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if !strings.Contains(m2.(tui.AppModel).View(), "Loading variables...") {
+		t.Errorf("expected variables to load when the pane opens, got:\n%s", m2.(tui.AppModel).View())
+	}
+	if msg := cmd().(tui.VariablesLoadedMsg); !errors.Is(msg.Err, domain.ErrNotSupported) || msg.PipelineID != "1001" {
+		t.Errorf("expected unsupported error for the pipeline, got %+v", msg)
+	}
+
+	m3, _ := m2.(tui.AppModel).Update(tui.VariablesLoadedMsg{PipelineID: "1001", Variables: domain.PipelineVariables{
+		Variables: []domain.Variable{
+			{Key: "DEPLOY_ENV", Value: "staging"},
+			{Key: "API_TOKEN", Value: "s3cr3t", Masked: true},
+		},
+	}})
+	view := m3.(tui.AppModel).View()
+	if !strings.Contains(view, "Variables (2)") || !strings.Contains(view, "staging") || !strings.Contains(view, "[masked]") {
+		t.Errorf("expected variables pane, got:\n%s", view)
+	}
+	if strings.Contains(view, "s3cr3t") {
+		t.Errorf("masked value must never be displayed, got:\n%s", view)
+	}
+
+	m4, _ := m3.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if strings.Contains(m4.(tui.AppModel).View(), "DEPLOY_ENV") {
+		t.Error("expected v to close the variables pane")
+	}
+}
+
+func TestApp_JobsView_VariablesPaneSaysWhyValuesAreHidden(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusSuccess}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, _ := m1.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m3, _ := m2.(tui.AppModel).Update(tui.VariablesLoadedMsg{PipelineID: "1001", Variables: domain.PipelineVariables{
+		Variables:    []domain.Variable{{Key: "DEPLOY_ENV", Value: "staging", Masked: true}},
+		ValuesHidden: "telling which variables are masked needs the Maintainer role on the project",
+	}})
+	view := m3.(tui.AppModel).View()
+	if !strings.Contains(view, "Values hidden: telling which variables are masked needs the Maintainer role") {
+		t.Errorf("expected the pane to say why values are hidden, got:\n%s", view)
+	}
+	if strings.Contains(view, "staging") {
+		t.Errorf("expected every value to be hidden, got:\n%s", view)
+	}
+}
+
+func TestApp_ChecksView_ReturnsToJobs(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", CommitSHA: "abc1234def", Status: domain.StatusSuccess}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m2, cmd := m1.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if msg := cmd().(tui.ChecksLoadedMsg); msg.Err == nil || msg.SHA != "abc1234def" {
+		t.Errorf("expected unsupported error for the pipeline's commit, got %+v", msg)
+	}
+	m3, _ := m2.(tui.AppModel).Update(tui.ChecksLoadedMsg{SHA: "abc1234def", Checks: []domain.CommitCheck{
+		{Name: "ci/jenkins", Kind: domain.CommitStatus, Status: domain.StatusFailed, URL: "https://jenkins.example.com/job/12"},
+	}})
+	view := m3.(tui.AppModel).View()
+	for _, want := range []string{"Checks for commit abc1234 (0 passed, 1 failed, 0 pending)", "ci/jenkins", "https://jenkins.example.com/job/12"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, view)
+		}
+	}
+
+	m4, _ := m3.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !strings.Contains(m4.(tui.AppModel).View(), "Jobs for Pipeline #1001") {
+		t.Errorf("expected esc to return to the jobs view, got:\n%s", m4.(tui.AppModel).View())
+	}
+}
+
+func TestApp_StatusBarShowsQuotaAndKeepsPipelinesWhileRateLimited(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusRunning}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{
+		Pipelines: pipelines,
+		RateLimit: &domain.RateLimit{Limit: 5000, Remaining: 4321},
+	})
+	if !strings.Contains(m0.(tui.AppModel).View(), "API quota: 4321/5000") {
+		t.Errorf("expected quota in status bar, got:\n%s", m0.(tui.AppModel).View())
+	}
+
+	retryAt := time.Date(2026, 1, 1, 10, 30, 0, 0, time.Local)
+	m1, _ := m0.(tui.AppModel).Update(tui.PipelinesLoadedMsg{
+		Err: &domain.RateLimitedError{Provider: "github", RetryAt: retryAt},
+	})
+	view := m1.(tui.AppModel).View()
+	if !strings.Contains(view, "#1001") || !strings.Contains(view, "rate limited until 10:30:00") {
+		t.Errorf("expected pipelines with a rate limit notice, got:\n%s", view)
+	}
+}
+
+func TestApp_TransientErrorKeepsPipelinesMarkedStale(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusRunning}}
+	provider := &fakeProvider{pipelines: pipelines}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tui.PipelinesLoadedMsg{
+		Err: &domain.TransientError{Err: errors.New("github API error: 503 Service Unavailable")},
+	})
+	view := m1.(tui.AppModel).View()
+	if strings.Contains(view, "Error:") || !strings.Contains(view, "#1001") || !strings.Contains(view, "stale: github API error: 503") {
+		t.Errorf("expected stale pipelines instead of an error screen, got:\n%s", view)
+	}
+
+	m2, _ := m1.(tui.AppModel).Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	if strings.Contains(m2.(tui.AppModel).View(), "stale") {
+		t.Errorf("expected a successful refresh to clear the stale marker, got:\n%s", m2.(tui.AppModel).View())
+	}
+
+	m3, _ := m2.(tui.AppModel).Update(tui.PipelinesLoadedMsg{Err: errors.New("github API error: 404 Not Found")})
+	if !strings.Contains(m3.(tui.AppModel).View(), "Error:") {
+		t.Errorf("expected a permanent error to be shown, got:\n%s", m3.(tui.AppModel).View())
+	}
+}
+
+func TestApp_ListAndDetailStalenessAreTrackedSeparately(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusRunning}}
+	detail := domain.Pipeline{ID: "1001", Branch: "main", Jobs: []domain.Job{{ID: "j1", Name: "build"}}}
+	blip := &domain.TransientError{Err: errors.New("github API error: 503 Service Unavailable")}
+	var m tea.Model = tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, &fakeProvider{pipelines: pipelines})
+
+	m, _ = m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(tui.PipelineDetailMsg{Pipeline: detail})
+	m, _ = m.Update(tui.PipelineDetailMsg{Err: blip})
+	m, _ = m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	if view := m.View(); !strings.Contains(view, "#1001 (stale)") {
+		t.Errorf("expected a list refresh to keep the failing detail marked stale, got:\n%s", view)
+	}
+
+	m, _ = m.Update(tui.PipelinesLoadedMsg{Err: blip})
+	m, _ = m.Update(tui.PipelineDetailMsg{Pipeline: detail})
+	if view := m.View(); strings.Contains(view, "(stale)") {
+		t.Errorf("expected a detail refresh to clear its stale mark, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if view := m.View(); !strings.Contains(view, "stale: github API error: 503") {
+		t.Errorf("expected a detail refresh to keep the failing list stale, got:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(tui.PipelineDetailMsg{Err: &domain.RateLimitedError{Provider: "github"}})
+	m, _ = m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	if view := m.View(); !strings.Contains(view, "#1001 (rate limited)") {
+		t.Errorf("expected a list refresh to keep the detail rate limit, got:\n%s", view)
+	}
+}
+
+func TestApp_StatusBarTellsCommitAuthorFromTriggeringUser(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", CommitAuthor: "waabox"}}
+	m, _ := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, &fakeProvider{pipelines: pipelines}).
+		Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	if view := m.View(); !strings.Contains(view, "#1001 on a commit by waabox") {
+		t.Errorf("expected the commit author to be labelled as such, got:\n%s", view)
+	}
+}
+
+func TestApp_ShowsWarningUnderHeader(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main"}}
+	app := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, &fakeProvider{pipelines: pipelines})
+	app.Warning = "TLS certificates are not verified"
+	var m tea.Model = app
+	m, _ = m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	if view := m.View(); !strings.Contains(view, "⚠ TLS certificates are not verified") {
+		t.Errorf("expected the warning in the pipelines view, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(tui.PipelineDetailMsg{Pipeline: pipelines[0]})
+	if view := m.View(); !strings.Contains(view, "⚠ TLS certificates are not verified") {
+		t.Errorf("expected the warning in the jobs view, got:\n%s", view)
+	}
+}
+
+func TestApp_CancelConflictShowsActionableMessage(t *testing.T) {
+	pipelines := []domain.Pipeline{{ID: "1001", Branch: "main", Status: domain.StatusSuccess}}
+	provider := &fakeProvider{pipelines: pipelines, cancelErr: &domain.APIError{
+		Provider: "github",
+		Status:   "409 Conflict",
+		Message:  "Cannot cancel a workflow run that is completed.",
+		Err:      domain.ErrConflict,
+	}}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Pipelines: pipelines})
+	m1, _ := m0.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m2, cmd := m1.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m3, _ := m2.(tui.AppModel).Update(cmd())
+	view := m3.(tui.AppModel).View()
+	if strings.Contains(view, "Error:") || !strings.Contains(view, "#1001") {
+		t.Errorf("expected the pipelines to stay on screen, got:\n%s", view)
+	}
+	if !strings.Contains(view, "could not cancel pipeline #1001: github API error: 409 Conflict: Cannot cancel a workflow run that is completed.") ||
+		!strings.Contains(view, "already finished") {
+		t.Errorf("expected the API message and a hint, got:\n%s", view)
+	}
+}
+
+func TestApp_ForbiddenErrorSuggestsScopes(t *testing.T) {
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, &fakeProvider{})
+	m0, _ := m.Update(tui.PipelinesLoadedMsg{Err: &domain.APIError{
+		Provider: "gitlab",
+		Status:   "403 Forbidden",
+		Message:  "insufficient_scope",
+		Err:      domain.ErrForbidden,
+	}})
+	view := m0.(tui.AppModel).View()
+	if !strings.Contains(view, "403 Forbidden: insufficient_scope") || !strings.Contains(view, "lacks the scope") {
+		t.Errorf("expected the API message and a hint, got:\n%s", view)
+	}
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// ArtifactListModel is an immutable model for the artifacts panel.
+type ArtifactListModel struct {
+	artifacts []domain.Artifact
+	cursor    int
+}
+
+// NewArtifactListModel creates an artifact list model.
+func NewArtifactListModel(artifacts []domain.Artifact) ArtifactListModel {
+	return ArtifactListModel{artifacts: artifacts, cursor: 0}
+}
+
+// MoveDown returns a new model with the cursor moved down by one.
+func (m ArtifactListModel) MoveDown() ArtifactListModel {
+	if m.cursor < len(m.artifacts)-1 {
+		m.cursor++
+	}
+	return m
+}
+
+// MoveUp returns a new model with the cursor moved up by one.
+func (m ArtifactListModel) MoveUp() ArtifactListModel {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+	return m
+}
+
+// Cursor returns the current cursor position.
+func (m ArtifactListModel) Cursor() int {
+	return m.cursor
+}
+
+// Artifacts returns the full artifact slice.
+func (m ArtifactListModel) Artifacts() []domain.Artifact {
+	return m.artifacts
+}
+
+// SelectedArtifact returns the currently highlighted artifact.
+// Returns false if the list is empty.
+func (m ArtifactListModel) SelectedArtifact() (domain.Artifact, bool) {
+	if len(m.artifacts) == 0 {
+		return domain.Artifact{}, false
+	}
+	return m.artifacts[m.cursor], true
+}
+
+// View renders the artifact list as a string with cursor indicators.
+func (m ArtifactListModel) View() string {
+	if len(m.artifacts) == 0 {
+		return "No artifacts found."
+	}
+	var sb strings.Builder
+	for i, a := range m.artifacts {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		sb.WriteString(fmt.Sprintf("%s%-30s %10s   %s\n",
+			prefix,
+			truncate(a.Name, 30),
+			formatBytes(a.Size),
+			formatExpiry(a),
+		))
+	}
+	return sb.String()
+}
+
+// formatBytes renders a byte count using binary units, e.g. "1.5 MiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// formatExpiry renders when an artifact expires relative to now.
+func formatExpiry(a domain.Artifact) string {
+	if a.Expired {
+		return "expired"
+	}
+	if a.ExpiresAt.IsZero() {
+		return "never expires"
+	}
+	d := time.Until(a.ExpiresAt)
+	switch {
+	case d <= 0:
+		return "expired"
+	case d < time.Hour:
+		return fmt.Sprintf("expires in %dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("expires in %dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("expires in %dd", int(d.Hours()/24))
+	}
+}
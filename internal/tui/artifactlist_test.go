@@ -0,0 +1,43 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/tui"
+)
+
+func TestArtifactListModel_RendersNameSizeAndExpiry(t *testing.T) {
+	artifacts := []domain.Artifact{
+		{ID: "1", Name: "test-report", Size: 1536, ExpiresAt: time.Now().Add(72 * time.Hour)},
+		{ID: "2", Name: "binary", Size: 10, Expired: true},
+	}
+	m := tui.NewArtifactListModel(artifacts)
+	view := m.View()
+	for _, want := range []string{"test-report", "1.5 KiB", "expires in", "binary", "expired"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestArtifactListModel_EmptyShowsMessage(t *testing.T) {
+	m := tui.NewArtifactListModel(nil)
+	if !strings.Contains(m.View(), "No artifacts") {
+		t.Errorf("expected empty message, got:\n%s", m.View())
+	}
+	if _, ok := m.SelectedArtifact(); ok {
+		t.Error("expected no selected artifact for empty list")
+	}
+}
+
+func TestArtifactListModel_NavigateDown(t *testing.T) {
+	m := tui.NewArtifactListModel([]domain.Artifact{{Name: "a"}, {Name: "b"}})
+	m = m.MoveDown()
+	selected, _ := m.SelectedArtifact()
+	if selected.Name != "b" {
+		t.Errorf("expected 'b' selected, got '%s'", selected.Name)
+	}
+}
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// attemptColumnWidth is the width of each attempt column in the comparison grid.
+const attemptColumnWidth = 14
+
+// AttemptsModel is an immutable model comparing the attempts of a pipeline's
+// jobs side by side: one row per job name and one column per attempt.
+type AttemptsModel struct {
+	names    []string
+	jobs     map[string]map[int]domain.Job
+	attempts int
+	row      int
+	col      int
+}
+
+// NewAttemptsModel creates an attempts model from every try of every job.
+// Jobs without an attempt number are treated as the first attempt.
+// The cursor starts on the latest attempt of the first job.
+func NewAttemptsModel(jobs []domain.Job) AttemptsModel {
+	m := AttemptsModel{jobs: make(map[string]map[int]domain.Job)}
+	for _, j := range jobs {
+		attempt := j.Attempt
+		if attempt < 1 {
+			attempt = 1
+		}
+		if _, ok := m.jobs[j.Name]; !ok {
+			m.names = append(m.names, j.Name)
+			m.jobs[j.Name] = make(map[int]domain.Job)
+		}
+		m.jobs[j.Name][attempt] = j
+		if attempt > m.attempts {
+			m.attempts = attempt
+		}
+	}
+	if m.attempts > 0 {
+		m.col = m.attempts - 1
+	}
+	return m
+}
+
+// UpdateJobs returns a new model with refreshed job data while preserving
+// the cursor position where possible.
+func (m AttemptsModel) UpdateJobs(jobs []domain.Job) AttemptsModel {
+	updated := NewAttemptsModel(jobs)
+	if m.row < len(updated.names) {
+		updated.row = m.row
+	}
+	if m.col < updated.attempts {
+		updated.col = m.col
+	}
+	return updated
+}
+
+// MoveDown returns a new model with the cursor moved to the next job.
+func (m AttemptsModel) MoveDown() AttemptsModel {
+	if m.row < len(m.names)-1 {
+		m.row++
+	}
+	return m
+}
+
+// MoveUp returns a new model with the cursor moved to the previous job.
+func (m AttemptsModel) MoveUp() AttemptsModel {
+	if m.row > 0 {
+		m.row--
+	}
+	return m
+}
+
+// MoveRight returns a new model with the cursor moved to the next attempt.
+func (m AttemptsModel) MoveRight() AttemptsModel {
+	if m.col < m.attempts-1 {
+		m.col++
+	}
+	return m
+}
+
+// MoveLeft returns a new model with the cursor moved to the previous attempt.
+func (m AttemptsModel) MoveLeft() AttemptsModel {
+	if m.col > 0 {
+		m.col--
+	}
+	return m
+}
+
+// Attempts returns the number of attempts shown.
+func (m AttemptsModel) Attempts() int {
+	return m.attempts
+}
+
+// SelectedJob returns the job try under the cursor.
+// Returns false if the job did not run in the selected attempt.
+func (m AttemptsModel) SelectedJob() (domain.Job, bool) {
+	if len(m.names) == 0 {
+		return domain.Job{}, false
+	}
+	j, ok := m.jobs[m.names[m.row]][m.col+1]
+	return j, ok
+}
+
+// View renders the comparison grid with the selected cell in brackets.
+func (m AttemptsModel) View() string {
+	if len(m.names) == 0 {
+		return "No jobs found."
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("  %-25s", "Job"))
+	for a := 1; a <= m.attempts; a++ {
+		sb.WriteString(" " + padCell(fmt.Sprintf(" attempt %d", a)))
+	}
+	sb.WriteString("\n")
+	for i, name := range m.names {
+		prefix := "  "
+		if i == m.row {
+			prefix = "> "
+		}
+		sb.WriteString(fmt.Sprintf("%s%-25s", prefix, truncate(name, 25)))
+		for a := 1; a <= m.attempts; a++ {
+			cell := " -"
+			if j, ok := m.jobs[name][a]; ok {
+				cell = fmt.Sprintf(" %s %s", statusIcon(j.Status), formatDuration(j.Duration))
+			}
+			if i == m.row && a == m.col+1 {
+				cell = "[" + cell[1:] + "]"
+			}
+			sb.WriteString(" " + padCell(cell))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// padCell pads s with spaces to the attempt column width. Status icons are
+// multi-byte, so the width is counted in runes rather than bytes.
+func padCell(s string) string {
+	if n := utf8.RuneCountInString(s); n < attemptColumnWidth {
+		return s + strings.Repeat(" ", attemptColumnWidth-n)
+	}
+	return s
+}
@@ -0,0 +1,50 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/tui"
+)
+
+func TestAttemptsModel_GroupsJobsByNameAndAttempt(t *testing.T) {
+	jobs := []domain.Job{
+		{ID: "1", Name: "build", Attempt: 1, Status: domain.StatusSuccess},
+		{ID: "2", Name: "test", Attempt: 1, Status: domain.StatusFailed},
+		{ID: "3", Name: "test", Attempt: 2, Status: domain.StatusSuccess},
+	}
+
+	m := tui.NewAttemptsModel(jobs)
+
+	if m.Attempts() != 2 {
+		t.Fatalf("expected 2 attempts, got %d", m.Attempts())
+	}
+	view := m.View()
+	if !strings.Contains(view, "attempt 1") || !strings.Contains(view, "attempt 2") {
+		t.Errorf("expected a column per attempt, got:\n%s", view)
+	}
+	// build only ran in the first attempt, and the cursor starts on the latest.
+	if _, ok := m.SelectedJob(); ok {
+		t.Error("expected no build job in attempt 2")
+	}
+	job, ok := m.MoveDown().SelectedJob()
+	if !ok || job.ID != "3" {
+		t.Errorf("expected latest test attempt, got %+v", job)
+	}
+	job, ok = m.MoveDown().MoveLeft().SelectedJob()
+	if !ok || job.ID != "2" {
+		t.Errorf("expected first test attempt, got %+v", job)
+	}
+}
+
+func TestAttemptsModel_TreatsUnnumberedJobsAsFirstAttempt(t *testing.T) {
+	m := tui.NewAttemptsModel([]domain.Job{{ID: "1", Name: "build"}})
+
+	if m.Attempts() != 1 {
+		t.Errorf("expected 1 attempt, got %d", m.Attempts())
+	}
+	if job, ok := m.SelectedJob(); !ok || job.ID != "1" {
+		t.Errorf("expected build to be selected, got %+v", job)
+	}
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// CheckListModel is an immutable model for the commit checks panel.
+type CheckListModel struct {
+	checks []domain.CommitCheck
+	cursor int
+}
+
+// NewCheckListModel creates a commit check list model.
+func NewCheckListModel(checks []domain.CommitCheck) CheckListModel {
+	return CheckListModel{checks: checks, cursor: 0}
+}
+
+// MoveDown returns a new model with the cursor moved down by one.
+func (m CheckListModel) MoveDown() CheckListModel {
+	if m.cursor < len(m.checks)-1 {
+		m.cursor++
+	}
+	return m
+}
+
+// MoveUp returns a new model with the cursor moved up by one.
+func (m CheckListModel) MoveUp() CheckListModel {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+	return m
+}
+
+// Cursor returns the current cursor position.
+func (m CheckListModel) Cursor() int {
+	return m.cursor
+}
+
+// SelectedCheck returns the currently highlighted check.
+// Returns false if the list is empty.
+func (m CheckListModel) SelectedCheck() (domain.CommitCheck, bool) {
+	if len(m.checks) == 0 {
+		return domain.CommitCheck{}, false
+	}
+	return m.checks[m.cursor], true
+}
+
+// Summary counts the checks by outcome, e.g. "3 passed, 1 failed, 2 pending".
+func (m CheckListModel) Summary() string {
+	var passed, failed, pending, other int
+	for _, c := range m.checks {
+		switch c.Status {
+		case domain.StatusSuccess:
+			passed++
+		case domain.StatusFailed:
+			failed++
+		case domain.StatusPending, domain.StatusRunning:
+			pending++
+		default:
+			other++
+		}
+	}
+	summary := fmt.Sprintf("%d passed, %d failed, %d pending", passed, failed, pending)
+	if other > 0 {
+		summary += fmt.Sprintf(", %d cancelled", other)
+	}
+	return summary
+}
+
+// View renders the check list with the kind, source and description of each check.
+func (m CheckListModel) View() string {
+	if len(m.checks) == 0 {
+		return "No checks reported for this commit."
+	}
+	var sb strings.Builder
+	for i, c := range m.checks {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %-30s %-12s %-15s %s\n",
+			prefix,
+			statusIcon(c.Status),
+			truncate(c.Name, 30),
+			c.Kind,
+			truncate(c.Source, 15),
+			truncate(firstLine(c.Description), 60),
+		))
+	}
+	return sb.String()
+}
@@ -0,0 +1,29 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/tui"
+)
+
+func TestCheckListModel_RendersChecksAndSummary(t *testing.T) {
+	checks := []domain.CommitCheck{
+		{Name: "build", Kind: domain.CheckRun, Source: "GitHub Actions", Status: domain.StatusSuccess},
+		{Name: "ci/jenkins", Kind: domain.CommitStatus, Status: domain.StatusFailed, Description: "Build #12 failed"},
+		{Name: "codecov", Kind: domain.CommitStatus, Status: domain.StatusPending},
+	}
+
+	m := tui.NewCheckListModel(checks)
+	view := m.View()
+
+	for _, want := range []string{"build", "check run", "GitHub Actions", "ci/jenkins", "Build #12 failed", "codecov"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, view)
+		}
+	}
+	if got := m.Summary(); got != "1 passed, 1 failed, 1 pending" {
+		t.Errorf("unexpected summary: %q", got)
+	}
+}
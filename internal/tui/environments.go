@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// EnvironmentListModel is an immutable model for the environments panel.
+type EnvironmentListModel struct {
+	environments []domain.Environment
+	cursor       int
+}
+
+// NewEnvironmentListModel creates an environment list model.
+func NewEnvironmentListModel(environments []domain.Environment) EnvironmentListModel {
+	return EnvironmentListModel{environments: environments, cursor: 0}
+}
+
+// MoveDown returns a new model with the cursor moved down by one.
+func (m EnvironmentListModel) MoveDown() EnvironmentListModel {
+	if m.cursor < len(m.environments)-1 {
+		m.cursor++
+	}
+	return m
+}
+
+// MoveUp returns a new model with the cursor moved up by one.
+func (m EnvironmentListModel) MoveUp() EnvironmentListModel {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+	return m
+}
+
+// Cursor returns the current cursor position.
+func (m EnvironmentListModel) Cursor() int {
+	return m.cursor
+}
+
+// SelectedEnvironment returns the currently highlighted environment.
+// Returns false if the list is empty.
+func (m EnvironmentListModel) SelectedEnvironment() (domain.Environment, bool) {
+	if len(m.environments) == 0 {
+		return domain.Environment{}, false
+	}
+	return m.environments[m.cursor], true
+}
+
+// View renders the environment list with the last deployment of each environment.
+func (m EnvironmentListModel) View() string {
+	if len(m.environments) == 0 {
+		return "No environments found."
+	}
+	var sb strings.Builder
+	for i, env := range m.environments {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		d := env.LastDeployment
+		if d == nil {
+			sb.WriteString(fmt.Sprintf("%s  %-20s never deployed\n", prefix, truncate(env.Name, 20)))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %-20s %-20s %-7s %-15s %s",
+			prefix,
+			statusIcon(d.Status),
+			truncate(env.Name, 20),
+			truncate(d.Ref, 20),
+			shortSHA(d.CommitSHA),
+			truncate("by "+d.Author, 15),
+			formatAge(d.CreatedAt),
+		))
+		if d.PipelineID != "" {
+			sb.WriteString("   pipeline #" + d.PipelineID)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
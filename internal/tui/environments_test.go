@@ -0,0 +1,38 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/tui"
+)
+
+func TestEnvironmentListModel_RendersLastDeployments(t *testing.T) {
+	environments := []domain.Environment{
+		{Name: "production", LastDeployment: &domain.Deployment{
+			Ref: "v1.2.0", CommitSHA: "abc1234def", Status: domain.StatusSuccess, Author: "waabox", PipelineID: "1001",
+		}},
+		{Name: "sandbox"},
+	}
+
+	view := tui.NewEnvironmentListModel(environments).View()
+
+	for _, want := range []string{"production", "v1.2.0", "abc1234", "by waabox", "pipeline #1001", "sandbox", "never deployed"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestEnvironmentListModel_SelectedEnvironment(t *testing.T) {
+	m := tui.NewEnvironmentListModel([]domain.Environment{{Name: "staging"}, {Name: "production"}})
+
+	env, ok := m.MoveDown().SelectedEnvironment()
+	if !ok || env.Name != "production" {
+		t.Errorf("expected production, got %+v", env)
+	}
+	if _, ok := tui.NewEnvironmentListModel(nil).SelectedEnvironment(); ok {
+		t.Error("expected no selection in an empty list")
+	}
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/waabox/gitdeck/internal/logscan"
+)
+
+// FindingListModel is an immutable model for the errors panel of a job.
+type FindingListModel struct {
+	findings []logscan.Finding
+	cursor   int
+}
+
+// NewFindingListModel creates a finding list model.
+func NewFindingListModel(findings []logscan.Finding) FindingListModel {
+	return FindingListModel{findings: findings, cursor: 0}
+}
+
+// MoveDown returns a new model with the cursor moved down by one.
+func (m FindingListModel) MoveDown() FindingListModel {
+	if m.cursor < len(m.findings)-1 {
+		m.cursor++
+	}
+	return m
+}
+
+// MoveUp returns a new model with the cursor moved up by one.
+func (m FindingListModel) MoveUp() FindingListModel {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+	return m
+}
+
+// Cursor returns the current cursor position.
+func (m FindingListModel) Cursor() int {
+	return m.cursor
+}
+
+// Findings returns the full finding slice.
+func (m FindingListModel) Findings() []logscan.Finding {
+	return m.findings
+}
+
+// View renders the findings with their log line numbers and cursor indicators.
+func (m FindingListModel) View() string {
+	if len(m.findings) == 0 {
+		return "No errors found in the job log."
+	}
+	var sb strings.Builder
+	for i, f := range m.findings {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		sb.WriteString(formatFinding(prefix, f))
+	}
+	return sb.String()
+}
+
+// formatFinding renders a single finding line, e.g. "> L42    test failure   --- FAIL: TestX".
+func formatFinding(prefix string, f logscan.Finding) string {
+	return fmt.Sprintf("%sL%-6d %-14s %s\n", prefix, f.Line+1, truncate(f.Rule, 14), truncate(f.Text, 80))
+}
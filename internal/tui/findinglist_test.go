@@ -0,0 +1,28 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/waabox/gitdeck/internal/logscan"
+	"github.com/waabox/gitdeck/internal/tui"
+)
+
+func TestFindingListModel_RendersLineNumbersAndRules(t *testing.T) {
+	m := tui.NewFindingListModel([]logscan.Finding{
+		{Line: 41, Rule: "test failure", Text: "--- FAIL: TestLogin"},
+	})
+	view := m.View()
+	for _, want := range []string{"L42", "test failure", "--- FAIL: TestLogin"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestFindingListModel_EmptyShowsMessage(t *testing.T) {
+	m := tui.NewFindingListModel(nil)
+	if !strings.Contains(m.View(), "No errors") {
+		t.Errorf("expected empty message, got:\n%s", m.View())
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/waabox/gitdeck/internal/domain"
 )
@@ -68,12 +69,41 @@ func (m JobDetailModel) render(focused bool) string {
 		if focused && i == m.cursor {
 			prefix = "> "
 		}
-		sb.WriteString(fmt.Sprintf("%s%s %-25s %s\n",
+		sb.WriteString(fmt.Sprintf("%s%s %-25s %s",
 			prefix,
 			statusIcon(j.Status),
 			truncate(j.Name, 25),
 			duration,
 		))
+		if j.Attempt > 1 {
+			sb.WriteString(fmt.Sprintf(" (attempt %d)", j.Attempt))
+		}
+		if j.Downstream != nil {
+			sb.WriteString(fmt.Sprintf("   ↳ pipeline #%s", j.Downstream.Pipeline.ID))
+		}
+		if runner := runnerInfo(j, time.Now()); runner != "" {
+			sb.WriteString("   " + runner)
+		}
+		sb.WriteString("\n")
 	}
 	return sb.String()
 }
+
+// runnerInfo describes which runner picked the job up and how long it waited,
+// or, for a job still waiting, how long it has been queued and which labels a
+// runner needs to pick it up.
+func runnerInfo(j domain.Job, now time.Time) string {
+	queued := j.QueuedDuration(now)
+	switch {
+	case j.Runner != "" && queued > 0:
+		return fmt.Sprintf("on %s after %s queued", j.Runner, formatDuration(queued))
+	case j.Runner != "":
+		return "on " + j.Runner
+	case j.Status == domain.StatusPending && queued > 0 && len(j.RunnerLabels) > 0:
+		return fmt.Sprintf("queued %s, needs runner [%s]", formatDuration(queued), strings.Join(j.RunnerLabels, ", "))
+	case j.Status == domain.StatusPending && queued > 0:
+		return fmt.Sprintf("queued %s", formatDuration(queued))
+	default:
+		return ""
+	}
+}
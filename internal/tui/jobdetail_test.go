@@ -1,6 +1,7 @@
 package tui_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -27,3 +28,19 @@ func TestJobDetailModel_EmptyShowsMessage(t *testing.T) {
 		t.Error("expected non-empty view for empty jobs")
 	}
 }
+
+func TestJobDetailModel_ShowsRunnerAndQueueTime(t *testing.T) {
+	queued := time.Now().Add(-3 * time.Minute)
+	jobs := []domain.Job{
+		{ID: "1", Name: "build", Status: domain.StatusRunning, QueuedAt: queued, StartedAt: queued.Add(20 * time.Second), Runner: "runner-1"},
+		{ID: "2", Name: "deploy", Status: domain.StatusPending, QueuedAt: queued, RunnerLabels: []string{"gpu"}},
+	}
+
+	view := tui.NewJobDetailModel(jobs).View()
+
+	for _, want := range []string{"on runner-1 after 20s queued", "queued 3m", "needs runner [gpu]"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, view)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package tui_test
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -90,3 +91,28 @@ func TestApp_LogView_ScrollDown_MovesOffset(t *testing.T) {
 		t.Errorf("expected line2 visible after scroll down, got:\n%s", view)
 	}
 }
+
+func TestApp_LogView_ShowsErrorsSummaryAndJumpsToFinding(t *testing.T) {
+	provider := &fakeProvider{}
+	m := tui.NewAppModel(domain.Repository{Owner: "waabox", Name: "gitdeck"}, provider)
+
+	lines := make([]string, 40)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line%d", i+1)
+	}
+	lines[29] = "--- FAIL: TestLogin (0.01s)"
+	m1, _ := m.Update(tui.LogsLoadedMsg{Content: strings.Join(lines, "\n"), JobName: "test"})
+	view := m1.(tui.AppModel).View()
+	if !strings.Contains(view, "Errors (1)") {
+		t.Errorf("expected errors summary in log view, got:\n%s", view)
+	}
+
+	m2, _ := m1.(tui.AppModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	view = m2.(tui.AppModel).View()
+	if strings.Contains(view, "line1\n") {
+		t.Errorf("expected log scrolled to the finding, got:\n%s", view)
+	}
+	if !strings.Contains(view, "> L30") {
+		t.Errorf("expected current finding highlighted, got:\n%s", view)
+	}
+}
@@ -82,17 +82,44 @@ func (m PipelineListModel) View() string {
 		if i == m.cursor {
 			prefix = "> "
 		}
-		sb.WriteString(fmt.Sprintf("%s%s #%s %-20s %s\n",
+		workflow := ""
+		if p.Workflow != "" {
+			workflow = fmt.Sprintf("%-16s ", truncate(p.Workflow, 16))
+		}
+		sb.WriteString(fmt.Sprintf("%s%s #%s %s%-20s %s",
 			prefix,
 			statusIcon(p.Status),
 			p.ID,
+			workflow,
 			truncate(p.Branch, 20),
 			formatAge(p.CreatedAt),
 		))
+		if p.Event != "" {
+			sb.WriteString("   " + p.Event)
+		}
+		if p.Attempt > 1 {
+			sb.WriteString(fmt.Sprintf(" (attempt %d)", p.Attempt))
+		}
+		if p.MergeRequest != nil {
+			sb.WriteString("   " + mergeRequestLabel(*p.MergeRequest))
+			if p.MergeRequest.Draft {
+				sb.WriteString(" (draft)")
+			}
+		}
+		sb.WriteString("\n")
 	}
 	return sb.String()
 }
 
+// mergeRequestLabel renders a short reference to a pull or merge request,
+// e.g. "PR #42" or "MR !42".
+func mergeRequestLabel(mr domain.MergeRequest) string {
+	if mr.Kind == domain.KindMergeRequest {
+		return fmt.Sprintf("MR !%d", mr.Number)
+	}
+	return fmt.Sprintf("PR #%d", mr.Number)
+}
+
 func statusIcon(s domain.PipelineStatus) string {
 	switch s {
 	case domain.StatusSuccess:
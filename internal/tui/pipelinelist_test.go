@@ -1,6 +1,7 @@
 package tui_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -111,3 +112,45 @@ func TestPipelineListModel_UpdatePipelines_ResetsWhenPipelineGone(t *testing.T)
 		t.Errorf("expected selected pipeline ID '1', got '%s'", m.SelectedPipeline().ID)
 	}
 }
+
+func TestPipelineListModel_ShowsPullRequest(t *testing.T) {
+	pipelines := []domain.Pipeline{
+		{ID: "100", Branch: "feat/auth", MergeRequest: &domain.MergeRequest{Number: 42, Draft: true}},
+		{ID: "99", Branch: "main"},
+	}
+
+	lines := strings.Split(tui.NewPipelineListModel(pipelines).View(), "\n")
+
+	if !strings.Contains(lines[0], "PR #42 (draft)") {
+		t.Errorf("expected pull request in first row, got %q", lines[0])
+	}
+	if strings.Contains(lines[1], "PR #") {
+		t.Errorf("expected no pull request in second row, got %q", lines[1])
+	}
+}
+
+func TestPipelineListModel_ShowsGitLabMergeRequest(t *testing.T) {
+	pipelines := []domain.Pipeline{
+		{ID: "100", Branch: "feat/auth", MergeRequest: &domain.MergeRequest{Kind: domain.KindMergeRequest, Number: 7}},
+	}
+
+	view := tui.NewPipelineListModel(pipelines).View()
+
+	if !strings.Contains(view, "MR !7") || strings.Contains(view, "PR #") {
+		t.Errorf("expected a merge request reference, got %q", view)
+	}
+}
+
+func TestPipelineListModel_ShowsWorkflowEventAndAttempt(t *testing.T) {
+	pipelines := []domain.Pipeline{
+		{ID: "100", Branch: "main", Workflow: "Deploy", Event: "push", Attempt: 3},
+	}
+
+	view := tui.NewPipelineListModel(pipelines).View()
+
+	for _, want := range []string{"Deploy", "push", "(attempt 3)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got %q", want, view)
+		}
+	}
+}
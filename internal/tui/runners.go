@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// RunnerListModel is an immutable model for the runners panel.
+type RunnerListModel struct {
+	runners []domain.Runner
+	cursor  int
+}
+
+// NewRunnerListModel creates a runner list model.
+func NewRunnerListModel(runners []domain.Runner) RunnerListModel {
+	return RunnerListModel{runners: runners, cursor: 0}
+}
+
+// MoveDown returns a new model with the cursor moved down by one.
+func (m RunnerListModel) MoveDown() RunnerListModel {
+	if m.cursor < len(m.runners)-1 {
+		m.cursor++
+	}
+	return m
+}
+
+// MoveUp returns a new model with the cursor moved up by one.
+func (m RunnerListModel) MoveUp() RunnerListModel {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+	return m
+}
+
+// Cursor returns the current cursor position.
+func (m RunnerListModel) Cursor() int {
+	return m.cursor
+}
+
+// SelectedRunner returns the currently highlighted runner.
+// Returns false if the list is empty.
+func (m RunnerListModel) SelectedRunner() (domain.Runner, bool) {
+	if len(m.runners) == 0 {
+		return domain.Runner{}, false
+	}
+	return m.runners[m.cursor], true
+}
+
+// Online returns how many of the runners are online.
+func (m RunnerListModel) Online() int {
+	n := 0
+	for _, r := range m.runners {
+		if r.Online {
+			n++
+		}
+	}
+	return n
+}
+
+// View renders the runner list with the status, scope and labels of each runner.
+func (m RunnerListModel) View() string {
+	if len(m.runners) == 0 {
+		return "No runners found."
+	}
+	var sb strings.Builder
+	for i, r := range m.runners {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %-25s %-8s %-10s %s\n",
+			prefix,
+			runnerIcon(r),
+			truncate(r.Name, 25),
+			runnerState(r),
+			truncate(r.Scope, 10),
+			strings.Join(r.Labels, ", "),
+		))
+	}
+	return sb.String()
+}
+
+// runnerIcon returns a status indicator for a runner.
+func runnerIcon(r domain.Runner) string {
+	switch {
+	case !r.Online:
+		return "✗"
+	case r.Paused:
+		return "○"
+	default:
+		return "●"
+	}
+}
+
+// runnerState describes whether a runner can pick up jobs.
+func runnerState(r domain.Runner) string {
+	switch {
+	case !r.Online:
+		return "offline"
+	case r.Paused:
+		return "paused"
+	case r.Busy:
+		return "busy"
+	default:
+		return "online"
+	}
+}
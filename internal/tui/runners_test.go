@@ -0,0 +1,28 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/tui"
+)
+
+func TestRunnerListModel_RendersOnlineStatus(t *testing.T) {
+	runners := []domain.Runner{
+		{Name: "build-box", Online: true, Busy: true, Scope: "repository", Labels: []string{"self-hosted", "linux"}},
+		{Name: "old-box", Scope: "group"},
+	}
+
+	m := tui.NewRunnerListModel(runners)
+	view := m.View()
+
+	for _, want := range []string{"build-box", "busy", "self-hosted, linux", "old-box", "offline"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, view)
+		}
+	}
+	if m.Online() != 1 {
+		t.Errorf("expected 1 online runner, got %d", m.Online())
+	}
+}
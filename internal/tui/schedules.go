@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// ScheduleListModel is an immutable model for the pipeline schedules panel.
+type ScheduleListModel struct {
+	schedules []domain.Schedule
+	cursor    int
+}
+
+// NewScheduleListModel creates a schedule list model.
+func NewScheduleListModel(schedules []domain.Schedule) ScheduleListModel {
+	return ScheduleListModel{schedules: schedules, cursor: 0}
+}
+
+// UpdateSchedules returns a new model with refreshed schedules while
+// preserving the cursor on the same schedule (matched by ID).
+func (m ScheduleListModel) UpdateSchedules(schedules []domain.Schedule) ScheduleListModel {
+	selected, ok := m.SelectedSchedule()
+	if ok {
+		for i, s := range schedules {
+			if s.ID == selected.ID {
+				return ScheduleListModel{schedules: schedules, cursor: i}
+			}
+		}
+	}
+	return NewScheduleListModel(schedules)
+}
+
+// MoveDown returns a new model with the cursor moved down by one.
+func (m ScheduleListModel) MoveDown() ScheduleListModel {
+	if m.cursor < len(m.schedules)-1 {
+		m.cursor++
+	}
+	return m
+}
+
+// MoveUp returns a new model with the cursor moved up by one.
+func (m ScheduleListModel) MoveUp() ScheduleListModel {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+	return m
+}
+
+// Cursor returns the current cursor position.
+func (m ScheduleListModel) Cursor() int {
+	return m.cursor
+}
+
+// SelectedSchedule returns the currently highlighted schedule.
+// Returns false if the list is empty.
+func (m ScheduleListModel) SelectedSchedule() (domain.Schedule, bool) {
+	if len(m.schedules) == 0 {
+		return domain.Schedule{}, false
+	}
+	return m.schedules[m.cursor], true
+}
+
+// View renders the schedule list with the next run and last run status of each schedule.
+func (m ScheduleListModel) View() string {
+	if len(m.schedules) == 0 {
+		return "No schedules found."
+	}
+	var sb strings.Builder
+	for i, s := range m.schedules {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		icon := " "
+		if s.LastStatus != "" {
+			icon = statusIcon(s.LastStatus)
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %-25s %-12s %-15s %s",
+			prefix,
+			icon,
+			truncate(s.Description, 25),
+			truncate(s.Ref, 12),
+			truncate(s.Cron, 15),
+			formatNextRun(s),
+		))
+		if s.LastPipelineID != "" {
+			sb.WriteString("   last #" + s.LastPipelineID)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// formatNextRun renders when a schedule runs next relative to now.
+func formatNextRun(s domain.Schedule) string {
+	if !s.Active {
+		return "inactive"
+	}
+	if s.NextRunAt.IsZero() {
+		return "next run unknown"
+	}
+	d := time.Until(s.NextRunAt)
+	switch {
+	case d <= 0:
+		return "due now"
+	case d < time.Hour:
+		return fmt.Sprintf("next in %dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("next in %dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("next in %dd", int(d.Hours()/24))
+	}
+}
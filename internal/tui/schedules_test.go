@@ -0,0 +1,37 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/tui"
+)
+
+func TestScheduleListModel_RendersNextRunAndLastStatus(t *testing.T) {
+	schedules := []domain.Schedule{
+		{ID: "1", Description: "nightly", Ref: "main", Cron: "0 3 * * *", Active: true,
+			NextRunAt: time.Now().Add(90 * time.Minute), LastStatus: domain.StatusFailed, LastPipelineID: "301"},
+		{ID: "2", Description: "weekly", Ref: "main", Cron: "0 0 * * 0"},
+	}
+
+	view := tui.NewScheduleListModel(schedules).View()
+
+	for _, want := range []string{"nightly", "0 3 * * *", "next in 1h", "last #301", "weekly", "inactive"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestScheduleListModel_UpdatePreservesSelection(t *testing.T) {
+	m := tui.NewScheduleListModel([]domain.Schedule{{ID: "1"}, {ID: "2"}}).MoveDown()
+
+	m = m.UpdateSchedules([]domain.Schedule{{ID: "3"}, {ID: "1"}, {ID: "2"}})
+
+	s, ok := m.SelectedSchedule()
+	if !ok || s.ID != "2" {
+		t.Errorf("expected schedule 2 to stay selected, got %+v", s)
+	}
+}
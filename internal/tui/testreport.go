@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// maxStackTraceLines limits how much of the selected test's stack trace is shown.
+const maxStackTraceLines = 15
+
+// TestReportModel is an immutable model for the failing tests panel.
+type TestReportModel struct {
+	report domain.TestReport
+	failed []domain.TestCase
+	cursor int
+}
+
+// NewTestReportModel creates a test report model listing the report's failed cases.
+func NewTestReportModel(report domain.TestReport) TestReportModel {
+	return TestReportModel{report: report, failed: report.Failed(), cursor: 0}
+}
+
+// MoveDown returns a new model with the cursor moved down by one.
+func (m TestReportModel) MoveDown() TestReportModel {
+	if m.cursor < len(m.failed)-1 {
+		m.cursor++
+	}
+	return m
+}
+
+// MoveUp returns a new model with the cursor moved up by one.
+func (m TestReportModel) MoveUp() TestReportModel {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+	return m
+}
+
+// Cursor returns the current cursor position.
+func (m TestReportModel) Cursor() int {
+	return m.cursor
+}
+
+// Failed returns the failed and errored test cases.
+func (m TestReportModel) Failed() []domain.TestCase {
+	return m.failed
+}
+
+// View renders a summary line, the failed test cases and the message and
+// stack trace of the selected case, after any warnings about results that
+// could not be read.
+func (m TestReportModel) View() string {
+	var sb strings.Builder
+	for _, w := range m.report.Warnings {
+		sb.WriteString("! " + w + "\n")
+	}
+	if len(m.report.Warnings) > 0 {
+		sb.WriteString("\n")
+	}
+	if len(m.report.Cases) == 0 {
+		sb.WriteString("No test report found.")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("%d tests: %d passed, %d failed, %d errors, %d skipped\n\n",
+		len(m.report.Cases),
+		m.report.Count(domain.TestPassed),
+		m.report.Count(domain.TestFailed),
+		m.report.Count(domain.TestErrored),
+		m.report.Count(domain.TestSkipped),
+	))
+	if len(m.failed) == 0 {
+		sb.WriteString("All tests passed.\n")
+		return sb.String()
+	}
+	for i, c := range m.failed {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		name := c.Name
+		if c.ClassName != "" {
+			name = c.ClassName + "." + c.Name
+		}
+		sb.WriteString(fmt.Sprintf("%s✗ %-50s %s\n", prefix, truncate(name, 50), truncate(firstLine(c.Message), 60)))
+	}
+
+	selected := m.failed[m.cursor]
+	sb.WriteString("\n")
+	if selected.Message != "" {
+		sb.WriteString(" " + selected.Message + "\n")
+	}
+	trace := strings.Split(strings.TrimRight(selected.StackTrace, "\n"), "\n")
+	if len(trace) > maxStackTraceLines {
+		trace = append(trace[:maxStackTraceLines], fmt.Sprintf("... %d more lines", len(trace)-maxStackTraceLines))
+	}
+	for _, line := range trace {
+		if line != "" {
+			sb.WriteString("   " + line + "\n")
+		}
+	}
+	return sb.String()
+}
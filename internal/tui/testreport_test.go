@@ -0,0 +1,63 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/tui"
+)
+
+func TestTestReportModel_ListsFailedCasesWithDetails(t *testing.T) {
+	report := domain.TestReport{Cases: []domain.TestCase{
+		{Name: "TestOK", Status: domain.TestPassed},
+		{ClassName: "auth", Name: "TestLogin", Status: domain.TestFailed, Message: "expected 200", StackTrace: "auth_test.go:42"},
+		{Name: "TestSkip", Status: domain.TestSkipped},
+	}}
+	m := tui.NewTestReportModel(report)
+	view := m.View()
+	for _, want := range []string{"3 tests: 1 passed, 1 failed", "auth.TestLogin", "expected 200", "auth_test.go:42"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "TestOK") {
+		t.Errorf("expected passing tests not to be listed, got:\n%s", view)
+	}
+}
+
+func TestTestReportModel_AllPassed(t *testing.T) {
+	m := tui.NewTestReportModel(domain.TestReport{Cases: []domain.TestCase{{Name: "a", Status: domain.TestPassed}}})
+	if !strings.Contains(m.View(), "All tests passed") {
+		t.Errorf("expected all passed message, got:\n%s", m.View())
+	}
+}
+
+func TestTestReportModel_EmptyShowsMessage(t *testing.T) {
+	m := tui.NewTestReportModel(domain.TestReport{})
+	if !strings.Contains(m.View(), "No test report") {
+		t.Errorf("expected empty message, got:\n%s", m.View())
+	}
+}
+
+func TestTestReportModel_ShowsWarnings(t *testing.T) {
+	m := tui.NewTestReportModel(domain.TestReport{Warnings: []string{"artifact junit skipped: not found"}})
+	view := m.View()
+	if !strings.Contains(view, "artifact junit skipped: not found") || !strings.Contains(view, "No test report") {
+		t.Errorf("expected the warning above the empty message, got:\n%s", view)
+	}
+}
+
+func TestTestReportModel_NavigateShowsSelectedDetails(t *testing.T) {
+	report := domain.TestReport{Cases: []domain.TestCase{
+		{Name: "first", Status: domain.TestFailed, Message: "first failure"},
+		{Name: "second", Status: domain.TestErrored, Message: "second failure", StackTrace: "second trace"},
+	}}
+	m := tui.NewTestReportModel(report).MoveDown()
+	if m.Cursor() != 1 {
+		t.Fatalf("expected cursor 1, got %d", m.Cursor())
+	}
+	if !strings.Contains(m.View(), "second trace") {
+		t.Errorf("expected selected stack trace in view, got:\n%s", m.View())
+	}
+}
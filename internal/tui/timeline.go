@@ -0,0 +1,262 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// timelineSpan is the resolved queue and run interval of a single job.
+type timelineSpan struct {
+	queued  time.Time
+	started time.Time
+	ended   time.Time
+}
+
+// TimelineModel is an immutable model for the Gantt-style timeline of a pipeline's jobs.
+// Each job is drawn as a horizontal bar across the pipeline's lifetime, split into
+// queue time and run time. Jobs on the critical path are highlighted.
+type TimelineModel struct {
+	jobs     []domain.Job
+	spans    []timelineSpan
+	critical map[int]bool
+	start    time.Time
+	end      time.Time
+	cursor   int
+}
+
+// NewTimelineModel creates a timeline model for the given jobs.
+// now is used as the end time of jobs that are still queued or running.
+func NewTimelineModel(jobs []domain.Job, now time.Time) TimelineModel {
+	m := TimelineModel{jobs: jobs, spans: make([]timelineSpan, len(jobs))}
+	for i, j := range jobs {
+		span := jobSpan(j, now)
+		m.spans[i] = span
+		if span.queued.IsZero() {
+			continue
+		}
+		if m.start.IsZero() || span.queued.Before(m.start) {
+			m.start = span.queued
+		}
+		if span.ended.After(m.end) {
+			m.end = span.ended
+		}
+	}
+	m.critical = criticalPath(m.spans)
+	return m
+}
+
+// jobSpan resolves the queue and run interval of a job. Missing timestamps are
+// filled in so that a job never ends before it starts: a job without a queue
+// time is treated as starting immediately, and unfinished jobs end at now.
+func jobSpan(j domain.Job, now time.Time) timelineSpan {
+	span := timelineSpan{queued: j.QueuedAt, started: j.StartedAt}
+	if span.queued.IsZero() && span.started.IsZero() && j.Queued > 0 {
+		span.queued = now.Add(-j.Queued)
+	}
+	if span.queued.IsZero() {
+		span.queued = span.started
+	}
+	if span.queued.IsZero() {
+		return timelineSpan{}
+	}
+	if !span.started.IsZero() && span.started.Before(span.queued) {
+		span.queued = span.started
+	}
+	switch {
+	case span.started.IsZero():
+		// Still waiting for a runner: the whole bar is queue time.
+		span.ended = now
+		if j.Status != domain.StatusPending && j.Status != domain.StatusRunning {
+			span.ended = span.queued
+		}
+	case j.Duration > 0:
+		span.ended = span.started.Add(j.Duration)
+	case j.Status == domain.StatusRunning:
+		span.ended = now
+	default:
+		span.ended = span.started
+	}
+	if span.ended.Before(span.started) {
+		span.ended = span.started
+	}
+	return span
+}
+
+// criticalPath returns the indexes of the jobs on the critical path.
+// Job dependencies are not exposed by the providers, so the path is inferred
+// backwards from the job that finished last: each predecessor is the job that
+// finished most recently before the current one entered the queue.
+func criticalPath(spans []timelineSpan) map[int]bool {
+	path := make(map[int]bool)
+	current := -1
+	for i, s := range spans {
+		if s.queued.IsZero() {
+			continue
+		}
+		if current < 0 || s.ended.After(spans[current].ended) {
+			current = i
+		}
+	}
+	for current >= 0 {
+		path[current] = true
+		ready := spans[current].queued
+		next := -1
+		for i, s := range spans {
+			if path[i] || s.queued.IsZero() || s.ended.After(ready) {
+				continue
+			}
+			if next < 0 || s.ended.After(spans[next].ended) {
+				next = i
+			}
+		}
+		current = next
+	}
+	return path
+}
+
+// UpdateJobs returns a new model with refreshed job data while preserving
+// the cursor position where possible.
+func (m TimelineModel) UpdateJobs(jobs []domain.Job, now time.Time) TimelineModel {
+	updated := NewTimelineModel(jobs, now)
+	if m.cursor < len(jobs) {
+		updated.cursor = m.cursor
+	}
+	return updated
+}
+
+// MoveDown returns a new model with the cursor moved down by one.
+func (m TimelineModel) MoveDown() TimelineModel {
+	if m.cursor < len(m.jobs)-1 {
+		m.cursor++
+	}
+	return m
+}
+
+// MoveUp returns a new model with the cursor moved up by one.
+func (m TimelineModel) MoveUp() TimelineModel {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+	return m
+}
+
+// Cursor returns the current cursor position.
+func (m TimelineModel) Cursor() int {
+	return m.cursor
+}
+
+// CriticalPath returns the jobs on the critical path in execution order.
+func (m TimelineModel) CriticalPath() []domain.Job {
+	var jobs []domain.Job
+	for i, j := range m.jobs {
+		if m.critical[i] {
+			jobs = append(jobs, j)
+		}
+	}
+	sort.SliceStable(jobs, func(a, b int) bool {
+		return jobs[a].StartedAt.Before(jobs[b].StartedAt)
+	})
+	return jobs
+}
+
+// WallTime returns the time between the first job being queued and the last job finishing.
+func (m TimelineModel) WallTime() time.Duration {
+	return m.end.Sub(m.start)
+}
+
+// View renders the timeline with bars scaled to fit the given terminal width.
+func (m TimelineModel) View(width int) string {
+	if len(m.jobs) == 0 {
+		return "No jobs found."
+	}
+	if width <= 0 {
+		width = 80
+	}
+	// prefix(2) + icon(1) + space + name(20) + space + bars + space + duration(14)
+	barWidth := width - 40
+	if barWidth < 20 {
+		barWidth = 20
+	}
+	total := m.WallTime()
+
+	var sb strings.Builder
+	for i, j := range m.jobs {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %-20s %s %s\n",
+			prefix,
+			statusIcon(j.Status),
+			truncate(j.Name, 20),
+			m.bar(i, barWidth, total),
+			formatSpan(m.spans[i]),
+		))
+	}
+	sb.WriteString(fmt.Sprintf("\n  ░ queued   █ running   ▓ critical path   wall time: %s\n",
+		formatDuration(total)))
+	return sb.String()
+}
+
+// bar renders the bar for job i: blanks before the job was queued, then
+// queue time, then run time.
+func (m TimelineModel) bar(i int, width int, total time.Duration) string {
+	span := m.spans[i]
+	if span.queued.IsZero() || total <= 0 {
+		return strings.Repeat(" ", width)
+	}
+	col := func(t time.Time) int {
+		c := int(float64(t.Sub(m.start)) / float64(total) * float64(width))
+		if c > width {
+			return width
+		}
+		return c
+	}
+	queuedCol := col(span.queued)
+	if span.started.IsZero() {
+		// Still queued: the whole bar is queue time.
+		endedCol := col(span.ended)
+		return strings.Repeat(" ", queuedCol) +
+			strings.Repeat("░", endedCol-queuedCol) +
+			strings.Repeat(" ", width-endedCol)
+	}
+	startedCol := col(span.started)
+	endedCol := col(span.ended)
+	if endedCol == startedCol && endedCol < width {
+		// Always show at least one cell of run time for jobs that started.
+		endedCol++
+	}
+	run := "█"
+	if m.critical[i] {
+		run = "▓"
+	}
+	return strings.Repeat(" ", queuedCol) +
+		strings.Repeat("░", startedCol-queuedCol) +
+		strings.Repeat(run, endedCol-startedCol) +
+		strings.Repeat(" ", width-endedCol)
+}
+
+// formatSpan renders the run and queue durations of a span, e.g. "1m20s q:5s".
+func formatSpan(s timelineSpan) string {
+	if s.queued.IsZero() {
+		return "--"
+	}
+	if s.started.IsZero() {
+		return "q:" + formatDuration(s.ended.Sub(s.queued))
+	}
+	return fmt.Sprintf("%s q:%s",
+		formatDuration(s.ended.Sub(s.started)),
+		formatDuration(s.started.Sub(s.queued)))
+}
+
+// formatDuration renders a duration with second precision, e.g. "1m20s".
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return d.Truncate(time.Second).String()
+}
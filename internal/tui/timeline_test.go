@@ -0,0 +1,77 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/waabox/gitdeck/internal/domain"
+	"github.com/waabox/gitdeck/internal/tui"
+)
+
+func TestTimelineModel_RendersQueueAndRunBars(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	jobs := []domain.Job{
+		{Name: "build", Status: domain.StatusSuccess, QueuedAt: start, StartedAt: start.Add(30 * time.Second), Duration: 90 * time.Second},
+	}
+	m := tui.NewTimelineModel(jobs, start.Add(2*time.Minute))
+	view := m.View(80)
+	if !strings.Contains(view, "build") {
+		t.Errorf("expected job name in view, got:\n%s", view)
+	}
+	if !strings.Contains(view, "░") {
+		t.Errorf("expected queue segment in view, got:\n%s", view)
+	}
+	if !strings.Contains(view, "1m30s q:30s") {
+		t.Errorf("expected run and queue durations in view, got:\n%s", view)
+	}
+	if !strings.Contains(view, "wall time: 2m0s") {
+		t.Errorf("expected wall time in view, got:\n%s", view)
+	}
+}
+
+func TestTimelineModel_CriticalPathFollowsLongestChain(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	jobs := []domain.Job{
+		{Name: "build", Status: domain.StatusSuccess, QueuedAt: start, StartedAt: start, Duration: 60 * time.Second},
+		{Name: "lint", Status: domain.StatusSuccess, QueuedAt: start, StartedAt: start, Duration: 20 * time.Second},
+		{Name: "test", Status: domain.StatusSuccess, QueuedAt: start.Add(60 * time.Second), StartedAt: start.Add(70 * time.Second), Duration: 120 * time.Second},
+		{Name: "docs", Status: domain.StatusSuccess, QueuedAt: start.Add(60 * time.Second), StartedAt: start.Add(60 * time.Second), Duration: 10 * time.Second},
+	}
+	m := tui.NewTimelineModel(jobs, start.Add(time.Hour))
+
+	path := m.CriticalPath()
+	if len(path) != 2 {
+		t.Fatalf("expected 2 jobs on the critical path, got %d: %v", len(path), path)
+	}
+	if path[0].Name != "build" || path[1].Name != "test" {
+		t.Errorf("expected critical path build → test, got %s → %s", path[0].Name, path[1].Name)
+	}
+}
+
+func TestTimelineModel_RunningJobExtendsToNow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	jobs := []domain.Job{
+		{Name: "deploy", Status: domain.StatusRunning, QueuedAt: start, StartedAt: start},
+	}
+	m := tui.NewTimelineModel(jobs, start.Add(45*time.Second))
+	if m.WallTime() != 45*time.Second {
+		t.Errorf("expected wall time 45s, got %s", m.WallTime())
+	}
+}
+
+func TestTimelineModel_EmptyShowsMessage(t *testing.T) {
+	m := tui.NewTimelineModel(nil, time.Now())
+	if !strings.Contains(m.View(80), "No jobs") {
+		t.Errorf("expected empty message, got:\n%s", m.View(80))
+	}
+}
+
+func TestTimelineModel_UpdateJobsPreservesCursor(t *testing.T) {
+	jobs := []domain.Job{{Name: "build"}, {Name: "test"}}
+	m := tui.NewTimelineModel(jobs, time.Now()).MoveDown()
+	m = m.UpdateJobs(jobs, time.Now())
+	if m.Cursor() != 1 {
+		t.Errorf("expected cursor 1, got %d", m.Cursor())
+	}
+}
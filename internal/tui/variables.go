@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/waabox/gitdeck/internal/domain"
+)
+
+// maskedValue is shown in place of the value of a masked variable.
+const maskedValue = "[masked]"
+
+// renderVariables renders pipeline variables as "key = value" rows.
+// Values of masked variables are never rendered.
+func renderVariables(variables []domain.Variable) string {
+	if len(variables) == 0 {
+		return "  No variables.\n"
+	}
+	var sb strings.Builder
+	for _, v := range variables {
+		value := firstLine(v.Value)
+		if v.Masked {
+			value = maskedValue
+		}
+		sb.WriteString(fmt.Sprintf("  %-30s = %s\n", truncate(v.Key, 30), truncate(value, 80)))
+	}
+	return sb.String()
+}